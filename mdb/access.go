@@ -43,6 +43,9 @@ var (
 
 	// DefaultIndexTimeout is the default timeout for index access.
 	DefaultIndexTimeout = 5 * time.Second
+
+	// DefaultFilesTimeout is the default timeout for file transfers.
+	DefaultFilesTimeout = time.Minute
 )
 
 // Config items for Mongo DB connection.
@@ -79,6 +82,9 @@ type Timeout struct {
 
 	// Timeout for indexes.
 	Index time.Duration
+
+	// Timeout for file transfers.
+	Files time.Duration
 }
 
 var ErrNoDbName = errors.New("no database name")
@@ -225,6 +231,10 @@ func fixConfig(config *Config) *Config {
 		config.Timeout.Index = DefaultIndexTimeout
 	}
 
+	if config.Timeout.Files == 0 {
+		config.Timeout.Files = DefaultFilesTimeout
+	}
+
 	return config
 }
 
@@ -275,6 +285,14 @@ type CollectionDefinition struct {
 	// Collection Finishers are run after creation of a collection.
 	// Finishers support mechanism such as index creation.
 	Finishers []CollectionFinisher
+
+	// SoftDelete causes Delete and DeleteAll to mark items as deleted
+	// by setting a timestamp field instead of removing them.
+	// Deleted items are then excluded from other operations.
+	SoftDelete bool
+
+	// SoftDeleteField is the name of the timestamp field, default DefaultSoftDeleteField.
+	SoftDeleteField string
 }
 
 // CollectionFinisher provides a way to add special processing when creating a collection.
@@ -295,6 +313,13 @@ func (a *Access) CollectionConnect(collection *Collection, definition *Collectio
 
 	collection.Access = a
 	collection.ctx = a.Context()
+	collection.softDelete = ""
+	if definition.SoftDelete {
+		collection.softDelete = definition.SoftDeleteField
+		if collection.softDelete == "" {
+			collection.softDelete = DefaultSoftDeleteField
+		}
+	}
 	connectCtx, cancelFn := collection.ContextWithTimeout()
 	defer cancelFn()
 
@@ -337,51 +362,3 @@ func (a *Access) CollectionConnect(collection *Collection, definition *Collectio
 
 	return nil
 }
-
-////////////////////////////////////////////////////////////////////////////////
-// Functions to check for specific, known errors.
-
-// IsDuplicate checks to see if the specified error is for attempting to create a duplicate document.
-func IsDuplicate(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	var e mongo.WriteException
-	if errors.As(err, &e) {
-		for _, we := range e.WriteErrors {
-			if we.Code == 11000 {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-// IsNotFound checks an error condition to see if it matches the underlying database "not found" error.
-func IsNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	return errors.Is(err, mongo.ErrNoDocuments)
-}
-
-// IsValidationFailure checks to see if the specified error is for a validation failure.
-func IsValidationFailure(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	var e mongo.WriteException
-	if errors.As(err, &e) {
-		for _, we := range e.WriteErrors {
-			if we.Code == 121 {
-				return true
-			}
-		}
-	}
-
-	return false
-}
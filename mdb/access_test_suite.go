@@ -53,7 +53,7 @@ func ConnectTypedCollectionHelper[T any](
 	collection, err := ConnectTypedCollection[T](suite.access, definition)
 	suite.Require().NoError(err)
 	suite.NotNil(collection)
-	suite.Require().NoError(collection.DeleteAll())
+	suite.Require().NoError(collection.Collection.DeleteAll())
 	for _, indexDescription := range indexDescriptions {
 		suite.Require().NoError(suite.access.Index(&collection.Collection, indexDescription))
 	}
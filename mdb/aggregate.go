@@ -0,0 +1,208 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Aggregate runs the aggregation pipeline on the collection and decodes the results.
+// The pipeline may be a Pipeline, a mongo.Pipeline, or any other pipeline accepted by mongo-go-driver.
+// Use the Collection embedded in a TypedCollection to aggregate a typed collection.
+func Aggregate[R any](collection *Collection, ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) ([]R, error) {
+	results := make([]R, 0)
+	err := AggregateIterate(collection, ctx, pipeline, func(result *R) error {
+		results = append(results, *result)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AggregateIterate runs the aggregation pipeline on the collection, applying the function to each result.
+// Each result is decoded into a new object so the function may keep it.
+// Return StopIteration from the function to end iteration early without an error.
+func AggregateIterate[R any](
+	collection *Collection, ctx context.Context, pipeline interface{},
+	fn func(result *R) error, opts ...*options.AggregateOptions) error {
+	cursor, err := collection.Collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return collection.opError("aggregate", nil, err)
+	}
+
+	return collection.opError("aggregate", nil, iterateCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		result := new(R)
+		if err := cursor.Decode(result); err != nil {
+			return fmt.Errorf("decode result: %w", err)
+		}
+		if err := fn(result); err != nil {
+			return fmt.Errorf("apply function: %w", err)
+		}
+		return nil
+	}))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Pipeline builds an aggregation pipeline one stage at a time:
+//
+//	pipeline := mdb.NewPipeline().
+//		Match(bson.D{{Key: "status", Value: "active"}}).
+//		Group("$region", bson.D{{Key: "total", Value: bson.D{{Key: "$sum", Value: "$amount"}}}}).
+//		Sort(bson.D{{Key: "total", Value: -1}})
+//
+// Each method returns a new Pipeline so a partial pipeline may be safely extended in different ways.
+type Pipeline []bson.D
+
+// NewPipeline returns an empty Pipeline.
+func NewPipeline() Pipeline {
+	return Pipeline{}
+}
+
+// Stage adds an arbitrary stage such as one without a specific method.
+func (p Pipeline) Stage(name string, value interface{}) Pipeline {
+	result := make(Pipeline, len(p), len(p)+1)
+	copy(result, p)
+	return append(result, bson.D{{Key: name, Value: value}})
+}
+
+// Match adds a $match stage passing only documents that match the filter.
+func (p Pipeline) Match(filter interface{}) Pipeline {
+	return p.Stage("$match", filter)
+}
+
+// Group adds a $group stage grouping documents by the id expression.
+// The fields are accumulator expressions computed for each group.
+func (p Pipeline) Group(id interface{}, fields bson.D) Pipeline {
+	group := make(bson.D, 0, len(fields)+1)
+	group = append(group, bson.E{Key: "_id", Value: id})
+	group = append(group, fields...)
+	return p.Stage("$group", group)
+}
+
+// Project adds a $project stage including, excluding, or computing fields.
+func (p Pipeline) Project(projection bson.D) Pipeline {
+	return p.Stage("$project", projection)
+}
+
+// Sort adds a $sort stage.
+func (p Pipeline) Sort(sort bson.D) Pipeline {
+	return p.Stage("$sort", sort)
+}
+
+// Skip adds a $skip stage.
+func (p Pipeline) Skip(count int64) Pipeline {
+	return p.Stage("$skip", count)
+}
+
+// Limit adds a $limit stage.
+func (p Pipeline) Limit(count int64) Pipeline {
+	return p.Stage("$limit", count)
+}
+
+// Unwind adds an $unwind stage producing a document for each element of the array at the path.
+// The path may be specified with or without the leading '$'.
+// Set preserveEmpty to keep documents where the array is missing, null, or empty.
+func (p Pipeline) Unwind(path string, preserveEmpty bool) Pipeline {
+	path = fieldPath(path)
+	if !preserveEmpty {
+		return p.Stage("$unwind", path)
+	}
+	return p.Stage("$unwind", bson.D{
+		{Key: "path", Value: path},
+		{Key: "preserveNullAndEmptyArrays", Value: true},
+	})
+}
+
+// Lookup adds a $lookup stage joining documents from another collection in the same database
+// where the local field equals the foreign field.
+// The matching documents are added as an array in the 'as' field.
+func (p Pipeline) Lookup(from, localField, foreignField, as string) Pipeline {
+	return p.Stage("$lookup", bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: localField},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	})
+}
+
+// Facet adds a $facet stage running each named sub-pipeline on the same input documents.
+// The result is a single document with a field containing the results of each sub-pipeline.
+func (p Pipeline) Facet(facets map[string]Pipeline) Pipeline {
+	names := make([]string, 0, len(facets))
+	for name := range facets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	facet := make(bson.D, 0, len(names))
+	for _, name := range names {
+		facet = append(facet, bson.E{Key: name, Value: facets[name]})
+	}
+	return p.Stage("$facet", facet)
+}
+
+// Bucket adds a $bucket stage grouping documents into ranges of the groupBy expression.
+// The boundaries must be sorted and specify the inclusive lower and exclusive upper bound of each bucket.
+// Documents outside the boundaries are put in the defaultID bucket, which is required if there are any.
+// Set defaultID to nil to leave it out.
+// The output fields are accumulator expressions, by default only a count is computed.
+func (p Pipeline) Bucket(groupBy interface{}, boundaries bson.A, defaultID interface{}, output bson.D) Pipeline {
+	bucket := bson.D{
+		{Key: "groupBy", Value: groupBy},
+		{Key: "boundaries", Value: boundaries},
+	}
+	if defaultID != nil {
+		bucket = append(bucket, bson.E{Key: "default", Value: defaultID})
+	}
+	if len(output) > 0 {
+		bucket = append(bucket, bson.E{Key: "output", Value: output})
+	}
+	return p.Stage("$bucket", bucket)
+}
+
+// MergeOptions configures a $merge stage.
+type MergeOptions struct {
+	// On lists the fields identifying matching documents, default _id.
+	// The target collection must have a unique index on these fields.
+	On []string
+
+	// WhenMatched is "replace", "keepExisting", "merge" (the default), "fail",
+	// or an update pipeline.
+	WhenMatched interface{}
+
+	// WhenNotMatched is "insert" (the default), "discard", or "fail".
+	WhenNotMatched string
+}
+
+// Merge adds a $merge stage writing the results into a collection in the same database.
+// It must be the last stage of the pipeline.
+func (p Pipeline) Merge(into string, opts *MergeOptions) Pipeline {
+	merge := bson.D{{Key: "into", Value: into}}
+	if opts != nil {
+		if len(opts.On) > 0 {
+			merge = append(merge, bson.E{Key: "on", Value: opts.On})
+		}
+		if opts.WhenMatched != nil {
+			merge = append(merge, bson.E{Key: "whenMatched", Value: opts.WhenMatched})
+		}
+		if opts.WhenNotMatched != "" {
+			merge = append(merge, bson.E{Key: "whenNotMatched", Value: opts.WhenNotMatched})
+		}
+	}
+	return p.Stage("$merge", merge)
+}
+
+// fieldPath returns the field name as a field path beginning with '$'.
+func fieldPath(field string) string {
+	if strings.HasPrefix(field, "$") {
+		return field
+	}
+	return "$" + field
+}
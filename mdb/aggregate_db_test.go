@@ -0,0 +1,164 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type aggregateDbTestSuite struct {
+	AccessTestSuite
+	typed  *TypedCollection[SimpleItem]
+	merged *Collection
+}
+
+func TestAggregateDbSuite(t *testing.T) {
+	suite.Run(t, new(aggregateDbTestSuite))
+}
+
+func (suite *aggregateDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollection)
+	suite.merged = suite.ConnectCollection(testCollectionMerged)
+}
+
+func (suite *aggregateDbTestSuite) SetupTest() {
+	for i := 0; i < 10; i++ {
+		suite.Require().NoError(suite.typed.Create(&SimpleItem{
+			Alpha:   fmt.Sprintf("Alpha #%d", i),
+			Bravo:   i,
+			Charlie: []string{"even", "odd"}[i%2],
+		}))
+	}
+}
+
+func (suite *aggregateDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+	suite.NoError(suite.merged.DeleteAll())
+}
+
+type charlieTotal struct {
+	Charlie string `bson:"_id"`
+	Count   int    `bson:"count"`
+	Total   int    `bson:"total"`
+}
+
+func (suite *aggregateDbTestSuite) TestGroup() {
+	pipeline := NewPipeline().
+		Match(bson.D{{Key: "bravo", Value: bson.D{{Key: "$gte", Value: 2}}}}).
+		Group("$charlie", bson.D{
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: "$bravo"}}},
+		}).
+		Sort(bson.D{{Key: "_id", Value: 1}})
+	results, err := Aggregate[charlieTotal](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Equal([]charlieTotal{
+		{Charlie: "even", Count: 4, Total: 2 + 4 + 6 + 8},
+		{Charlie: "odd", Count: 4, Total: 3 + 5 + 7 + 9},
+	}, results)
+}
+
+func (suite *aggregateDbTestSuite) TestTypedResults() {
+	pipeline := NewPipeline().Sort(bson.D{{Key: "bravo", Value: -1}}).Skip(1).Limit(2)
+	results, err := Aggregate[SimpleItem](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 2)
+	suite.Equal("Alpha #8", results[0].Alpha)
+	suite.Equal("Alpha #7", results[1].Alpha)
+}
+
+func (suite *aggregateDbTestSuite) TestEmpty() {
+	pipeline := NewPipeline().Match(bson.D{{Key: "bravo", Value: -1}})
+	results, err := Aggregate[SimpleItem](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.NotNil(results)
+	suite.Empty(results)
+}
+
+func (suite *aggregateDbTestSuite) TestIterate() {
+	pipeline := NewPipeline().Sort(bson.D{{Key: "bravo", Value: 1}})
+	var alpha []string
+	suite.NoError(AggregateIterate(&suite.typed.Collection, context.Background(), pipeline,
+		func(result *SimpleItem) error {
+			alpha = append(alpha, result.Alpha)
+			if len(alpha) == 3 {
+				return StopIteration
+			}
+			return nil
+		}))
+	suite.Equal([]string{"Alpha #0", "Alpha #1", "Alpha #2"}, alpha)
+	failure := errors.New("failure")
+	suite.ErrorIs(AggregateIterate(&suite.typed.Collection, context.Background(), pipeline,
+		func(result *SimpleItem) error {
+			return failure
+		}), failure)
+}
+
+func (suite *aggregateDbTestSuite) TestMongoPipeline() {
+	pipeline := mongo.Pipeline{{{Key: "$count", Value: "count"}}}
+	results, err := Aggregate[bson.M](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.EqualValues(10, results[0]["count"])
+}
+
+func (suite *aggregateDbTestSuite) TestUnwindLookup() {
+	suite.Require().NoError(suite.merged.Create(bson.D{
+		{Key: "_id", Value: "even"},
+		{Key: "tags", Value: bson.A{"a", "b"}},
+	}))
+	pipeline := NewPipeline().
+		Match(bson.D{{Key: "bravo", Value: 0}}).
+		Lookup(testCollectionMerged.Name, "charlie", "_id", "joined").
+		Unwind("joined", false).
+		Unwind("joined.tags", false).
+		Project(bson.D{{Key: "_id", Value: 0}, {Key: "tag", Value: "$joined.tags"}})
+	results, err := Aggregate[bson.M](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Equal([]bson.M{{"tag": "a"}, {"tag": "b"}}, results)
+}
+
+type bravoFacets struct {
+	Low     []SimpleItem `bson:"low"`
+	Buckets []struct {
+		ID    interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	} `bson:"buckets"`
+}
+
+func (suite *aggregateDbTestSuite) TestFacetBucket() {
+	pipeline := NewPipeline().Facet(map[string]Pipeline{
+		"low":     NewPipeline().Sort(bson.D{{Key: "bravo", Value: 1}}).Limit(2),
+		"buckets": NewPipeline().Bucket("$bravo", bson.A{0, 5}, "high", nil),
+	})
+	results, err := Aggregate[bravoFacets](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.Require().Len(results[0].Low, 2)
+	suite.Equal("Alpha #1", results[0].Low[1].Alpha)
+	suite.Require().Len(results[0].Buckets, 2)
+	suite.EqualValues(0, results[0].Buckets[0].ID)
+	suite.Equal(5, results[0].Buckets[0].Count)
+	suite.Equal("high", results[0].Buckets[1].ID)
+	suite.Equal(5, results[0].Buckets[1].Count)
+}
+
+func (suite *aggregateDbTestSuite) TestMerge() {
+	pipeline := NewPipeline().
+		Group("$charlie", bson.D{{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}).
+		Merge(testCollectionMerged.Name, &MergeOptions{WhenMatched: "replace"})
+	results, err := Aggregate[bson.M](&suite.typed.Collection, context.Background(), pipeline)
+	suite.Require().NoError(err)
+	suite.Empty(results)
+	count, err := suite.merged.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+}
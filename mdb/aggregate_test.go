@@ -0,0 +1,107 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type pipelineTestSuite struct {
+	suite.Suite
+}
+
+func TestPipelineSuite(t *testing.T) {
+	suite.Run(t, new(pipelineTestSuite))
+}
+
+func (suite *pipelineTestSuite) TestStages() {
+	pipeline := NewPipeline().
+		Match(bson.D{{Key: "charlie", Value: "x"}}).
+		Group("$bravo", bson.D{{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}).
+		Project(bson.D{{Key: "count", Value: 1}}).
+		Sort(bson.D{{Key: "count", Value: -1}}).
+		Skip(5).
+		Limit(10)
+	suite.Equal(Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "charlie", Value: "x"}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$bravo"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$project", Value: bson.D{{Key: "count", Value: 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+		{{Key: "$skip", Value: int64(5)}},
+		{{Key: "$limit", Value: int64(10)}},
+	}, pipeline)
+}
+
+func (suite *pipelineTestSuite) TestExtendIndependently() {
+	base := NewPipeline().Match(bson.D{{Key: "alpha", Value: "one"}})
+	first := base.Limit(1)
+	second := base.Limit(2)
+	suite.Len(base, 1)
+	suite.Equal(bson.D{{Key: "$limit", Value: int64(1)}}, first[1])
+	suite.Equal(bson.D{{Key: "$limit", Value: int64(2)}}, second[1])
+}
+
+func (suite *pipelineTestSuite) TestUnwind() {
+	suite.Equal(bson.D{{Key: "$unwind", Value: "$items"}}, NewPipeline().Unwind("items", false)[0])
+	suite.Equal(bson.D{{Key: "$unwind", Value: bson.D{
+		{Key: "path", Value: "$items"},
+		{Key: "preserveNullAndEmptyArrays", Value: true},
+	}}}, NewPipeline().Unwind("$items", true)[0])
+}
+
+func (suite *pipelineTestSuite) TestLookup() {
+	suite.Equal(bson.D{{Key: "$lookup", Value: bson.D{
+		{Key: "from", Value: "other"},
+		{Key: "localField", Value: "otherID"},
+		{Key: "foreignField", Value: "_id"},
+		{Key: "as", Value: "others"},
+	}}}, NewPipeline().Lookup("other", "otherID", "_id", "others")[0])
+}
+
+func (suite *pipelineTestSuite) TestFacet() {
+	byCount := NewPipeline().Limit(1)
+	bySort := NewPipeline().Sort(bson.D{{Key: "alpha", Value: 1}})
+	suite.Equal(bson.D{{Key: "$facet", Value: bson.D{
+		{Key: "first", Value: byCount},
+		{Key: "sorted", Value: bySort},
+	}}}, NewPipeline().Facet(map[string]Pipeline{"sorted": bySort, "first": byCount})[0])
+}
+
+func (suite *pipelineTestSuite) TestBucket() {
+	suite.Equal(bson.D{{Key: "$bucket", Value: bson.D{
+		{Key: "groupBy", Value: "$bravo"},
+		{Key: "boundaries", Value: bson.A{0, 10, 20}},
+	}}}, NewPipeline().Bucket("$bravo", bson.A{0, 10, 20}, nil, nil)[0])
+	suite.Equal(bson.D{{Key: "$bucket", Value: bson.D{
+		{Key: "groupBy", Value: "$bravo"},
+		{Key: "boundaries", Value: bson.A{0, 10}},
+		{Key: "default", Value: "other"},
+		{Key: "output", Value: bson.D{{Key: "names", Value: bson.D{{Key: "$push", Value: "$alpha"}}}}},
+	}}}, NewPipeline().Bucket("$bravo", bson.A{0, 10}, "other",
+		bson.D{{Key: "names", Value: bson.D{{Key: "$push", Value: "$alpha"}}}})[0])
+}
+
+func (suite *pipelineTestSuite) TestMerge() {
+	suite.Equal(bson.D{{Key: "$merge", Value: bson.D{{Key: "into", Value: "target"}}}},
+		NewPipeline().Merge("target", nil)[0])
+	suite.Equal(bson.D{{Key: "$merge", Value: bson.D{
+		{Key: "into", Value: "target"},
+		{Key: "on", Value: []string{"alpha"}},
+		{Key: "whenMatched", Value: "replace"},
+		{Key: "whenNotMatched", Value: "discard"},
+	}}}, NewPipeline().Merge("target", &MergeOptions{
+		On:             []string{"alpha"},
+		WhenMatched:    "replace",
+		WhenNotMatched: "discard",
+	})[0])
+}
+
+func (suite *pipelineTestSuite) TestMarshal() {
+	// The driver accepts Pipeline directly as a slice of documents.
+	_, err := bson.Marshal(bson.D{{Key: "pipeline", Value: NewPipeline().Limit(1)}})
+	suite.NoError(err)
+}
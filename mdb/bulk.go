@@ -0,0 +1,373 @@
+package mdb
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// DefaultBulkChunkSize is the default maximum number of operations sent to the server at once.
+var DefaultBulkChunkSize = 1000
+
+// ErrBulkItemsFailed is returned when one or more items in a bulk operation fail.
+// Details are available per item in the BulkResult.
+var ErrBulkItemsFailed = errors.New("bulk items failed")
+
+// ErrBulkNotAttempted is set on items in an ordered bulk operation
+// that were not attempted due to the failure of an earlier item.
+var ErrBulkNotAttempted = errors.New("bulk item not attempted")
+
+// BulkItemResult is the result for a single item in a bulk operation.
+type BulkItemResult struct {
+	// Index of the item in the order it was added to the bulk operation.
+	Index int
+
+	// InsertedID is the _id of the document for successful insert items.
+	InsertedID interface{}
+
+	// UpsertedID is the _id of the document for update or replace items that were upserted.
+	UpsertedID interface{}
+
+	// Err is the error for the item if it failed.
+	Err error
+
+	// Duplicate is true if the item failed due to a duplicate key.
+	Duplicate bool
+}
+
+// BulkResult contains aggregate counts and per-item results for a bulk operation.
+type BulkResult struct {
+	Inserted int64
+	Matched  int64
+	Modified int64
+	Deleted  int64
+	Upserted int64
+
+	// Items contains one result per item in the order the items were added.
+	Items []BulkItemResult
+}
+
+// Failed returns the results for items that failed or were not attempted.
+func (br *BulkResult) Failed() []BulkItemResult {
+	var failed []BulkItemResult
+	for _, item := range br.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Duplicates returns the indexes of items that failed due to a duplicate key.
+func (br *BulkResult) Duplicates() []int {
+	var duplicates []int
+	for _, item := range br.Items {
+		if item.Duplicate {
+			duplicates = append(duplicates, item.Index)
+		}
+	}
+	return duplicates
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// BulkWriter builds a bulk operation of insert, update, replace, and delete items.
+// Items are sent to the server in chunks when Execute is called.
+// By default the operation is ordered, so processing stops at the first failed item.
+type BulkWriter[T any] struct {
+	collection  *TypedCollection[T]
+	models      []mongo.WriteModel
+	insertedIDs map[int]interface{}
+	inserted    []*T
+	undo        map[int][]func()
+	ordered     bool
+	chunkSize   int
+	err         error
+}
+
+// Bulk returns a new bulk operation builder for the collection.
+func (c *TypedCollection[T]) Bulk() *BulkWriter[T] {
+	return &BulkWriter[T]{
+		collection:  c,
+		insertedIDs: make(map[int]interface{}),
+		undo:        make(map[int][]func()),
+		ordered:     true,
+		chunkSize:   DefaultBulkChunkSize,
+	}
+}
+
+// CreateMany items in the DB using a bulk operation.
+// The operation is ordered unless specified otherwise in the options.
+func (c *TypedCollection[T]) CreateMany(items []*T, opts ...*options.BulkWriteOptions) (*BulkResult, error) {
+	bulk := c.Bulk().Insert(items...)
+	if merged := options.MergeBulkWriteOptions(opts...); merged.Ordered != nil {
+		bulk.Ordered(*merged.Ordered)
+	}
+	return bulk.Execute()
+}
+
+// Ordered sets whether processing stops at the first failed item.
+func (bw *BulkWriter[T]) Ordered(ordered bool) *BulkWriter[T] {
+	bw.ordered = ordered
+	return bw
+}
+
+// ChunkSize sets the maximum number of items sent to the server at once.
+func (bw *BulkWriter[T]) ChunkSize(size int) *BulkWriter[T] {
+	if size > 0 {
+		bw.chunkSize = size
+	}
+	return bw
+}
+
+// Len returns the number of items in the bulk operation.
+func (bw *BulkWriter[T]) Len() int {
+	return len(bw.models)
+}
+
+// Insert items.
+// Items without an _id are assigned a new ObjectID so it can be returned in the item result.
+// Timestamped items have their timestamps set as for Create.
+// BeforeCreate hooks are called here and AfterCreate hooks by Execute, in insert order, for items that were inserted.
+func (bw *BulkWriter[T]) Insert(items ...*T) *BulkWriter[T] {
+	for _, item := range items {
+		if bw.err != nil {
+			break
+		}
+		if err := beforeCreate(bw.collection.ctx, item); err != nil {
+			bw.err = fmt.Errorf("insert item #%d: %w", len(bw.models), err)
+			break
+		}
+		if stamped, ok := any(item).(Timestamped); ok {
+			touchCreated(stamped)
+		}
+		document, id, err := documentWithID(item)
+		if err != nil {
+			bw.err = fmt.Errorf("insert item #%d: %w", len(bw.models), err)
+			break
+		}
+		bw.insertedIDs[len(bw.models)] = id
+		bw.add(mongo.NewInsertOneModel().SetDocument(document), item)
+	}
+	return bw
+}
+
+// Update a single item referenced by filter by applying update operator expressions.
+// Timestamped and Versioned items are handled as for TypedCollection.Update.
+// Soft deleted items are not matched.
+func (bw *BulkWriter[T]) Update(filter bson.D, changes interface{}, upsert bool) *BulkWriter[T] {
+	if bw.err != nil {
+		return bw
+	}
+	changes, err := updateChanges[T](changes)
+	if err != nil {
+		bw.err = fmt.Errorf("update item #%d: %w", len(bw.models), err)
+		return bw
+	}
+	bw.add(mongo.NewUpdateOneModel().
+		SetFilter(bw.collection.activeFilter(filter)).SetUpdate(changes).SetUpsert(upsert), nil)
+	return bw
+}
+
+// Replace a single item referenced by filter with the specified item.
+// If the item is Versioned the filter only matches the item with the same version,
+// which is incremented in the database and the item, and upsert only applies to a zero version.
+// Timestamped items have their timestamps set as for TypedCollection.ReplaceDocument.
+// The version and timestamps of the item are restored if the replacement fails,
+// but a stale version is not reported per item, it is only missing from the Matched count.
+// Soft deleted items are not matched.
+func (bw *BulkWriter[T]) Replace(filter bson.D, item *T, upsert bool) *BulkWriter[T] {
+	if bw.err != nil {
+		return bw
+	}
+	if err := beforeUpdate(bw.collection.ctx, item); err != nil {
+		bw.err = fmt.Errorf("replace item #%d: %w", len(bw.models), err)
+		return bw
+	}
+	if stamped, ok := any(item).(Timestamped); ok {
+		created, updated := stamped.CreatedTime(), stamped.UpdatedTime()
+		touchCreated(stamped)
+		bw.addUndo(func() {
+			stamped.SetCreatedTime(created)
+			stamped.SetUpdatedTime(updated)
+		})
+	}
+	var match interface{} = filter
+	if versioned, ok := any(item).(Versioned); ok {
+		version := versioned.CurrentVersion()
+		match = addCondition(filter, versionCondition(version))
+		upsert = upsert && version == 0
+		versioned.SetVersion(version + 1)
+		bw.addUndo(func() { versioned.SetVersion(version) })
+	}
+	bw.add(mongo.NewReplaceOneModel().
+		SetFilter(bw.collection.activeFilter(match)).SetReplacement(item).SetUpsert(upsert), nil)
+	return bw
+}
+
+// Delete a single item referenced by filter.
+// If soft delete is configured the item is marked as deleted instead of being removed,
+// so it is counted as modified rather than deleted in the result.
+func (bw *BulkWriter[T]) Delete(filter bson.D) *BulkWriter[T] {
+	if bw.err != nil {
+		return bw
+	}
+	if err := beforeDelete[T](bw.collection.ctx, filter); err != nil {
+		bw.err = fmt.Errorf("delete item #%d: %w", len(bw.models), err)
+		return bw
+	}
+	if bw.collection.SoftDeleted() {
+		bw.add(mongo.NewUpdateOneModel().
+			SetFilter(bw.collection.activeFilter(filter)).SetUpdate(bw.collection.softDeleteChanges()), nil)
+		return bw
+	}
+	bw.add(mongo.NewDeleteOneModel().SetFilter(filter), nil)
+	return bw
+}
+
+// addUndo adds a function that reverts a change to the item of the next model if it fails.
+func (bw *BulkWriter[T]) addUndo(undo func()) {
+	bw.undo[len(bw.models)] = append(bw.undo[len(bw.models)], undo)
+}
+
+// add a model with the inserted item at the same position, nil if the model is not an insert.
+func (bw *BulkWriter[T]) add(model mongo.WriteModel, inserted *T) {
+	bw.models = append(bw.models, model)
+	bw.inserted = append(bw.inserted, inserted)
+}
+
+// undoFrom reverts changes made to items starting at the specified index.
+func (bw *BulkWriter[T]) undoFrom(start int) {
+	for i, undos := range bw.undo {
+		if i >= start {
+			for _, undo := range undos {
+				undo()
+			}
+		}
+	}
+}
+
+// Execute the bulk operation.
+// The result contains per-item results even when an error is returned.
+// If any items fail the returned error wraps ErrBulkItemsFailed.
+func (bw *BulkWriter[T]) Execute() (*BulkResult, error) {
+	if bw.err != nil {
+		return nil, bw.collection.opError("bulk write", nil, bw.err)
+	}
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(bw.models))}
+	for i := range result.Items {
+		result.Items[i].Index = i
+		result.Items[i].InsertedID = bw.insertedIDs[i]
+	}
+
+	failed := 0
+	opts := options.BulkWrite().SetOrdered(bw.ordered)
+	for start := 0; start < len(bw.models); start += bw.chunkSize {
+		end := start + bw.chunkSize
+		if end > len(bw.models) {
+			end = len(bw.models)
+		}
+
+		chunkResult, err := bw.collection.BulkWrite(bw.collection.ctx, bw.models[start:end], opts)
+		if chunkResult != nil {
+			result.Inserted += chunkResult.InsertedCount
+			result.Matched += chunkResult.MatchedCount
+			result.Modified += chunkResult.ModifiedCount
+			result.Deleted += chunkResult.DeletedCount
+			result.Upserted += chunkResult.UpsertedCount
+			for index, id := range chunkResult.UpsertedIDs {
+				result.Items[start+int(index)].UpsertedID = id
+			}
+		}
+		if err == nil {
+			continue
+		}
+
+		var bwe mongo.BulkWriteException
+		if !errors.As(err, &bwe) || len(bwe.WriteErrors) < 1 {
+			// The chunk failed as a whole so the state of these items and any others is unknown.
+			bw.undoFrom(start)
+			return result, bw.collection.opError("bulk write", nil, fmt.Errorf("items %d-%d: %w", start, end-1, err))
+		}
+
+		for _, writeErr := range bwe.WriteErrors {
+			item := &result.Items[start+writeErr.Index]
+			item.Err = writeErr
+			item.InsertedID = nil
+			item.Duplicate = writeErr.Code == duplicateKeyCode
+			failed++
+		}
+
+		if bw.ordered {
+			// Processing stopped at the first failure.
+			for i := start + bwe.WriteErrors[0].Index + 1; i < len(result.Items); i++ {
+				result.Items[i].Err = ErrBulkNotAttempted
+				result.Items[i].InsertedID = nil
+				failed++
+			}
+			break
+		}
+	}
+
+	for i, undos := range bw.undo {
+		if result.Items[i].Err != nil {
+			for _, undo := range undos {
+				undo()
+			}
+		}
+	}
+
+	for i, item := range bw.inserted {
+		if item != nil && result.Items[i].Err == nil {
+			if err := afterCreate(bw.collection.ctx, item); err != nil {
+				return result, bw.collection.opError("bulk write", nil, fmt.Errorf("insert item #%d: %w", i, err))
+			}
+		}
+	}
+
+	if failed > 0 {
+		return result, bw.collection.opError("bulk write", nil,
+			fmt.Errorf("%w: %d of %d", ErrBulkItemsFailed, failed, len(result.Items)))
+	}
+
+	return result, nil
+}
+
+// documentWithID marshals the item and returns it with its _id,
+// adding a new ObjectID as the first field if the item did not provide one.
+func documentWithID(item interface{}) (bson.Raw, interface{}, error) {
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal item: %w", err)
+	}
+
+	if value, err := bson.Raw(raw).LookupErr("_id"); err == nil {
+		var id interface{}
+		if err = value.Unmarshal(&id); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal _id: %w", err)
+		}
+		return raw, id, nil
+	}
+
+	id := primitive.NewObjectID()
+	elements, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read elements: %w", err)
+	}
+	index, document := bsoncore.AppendDocumentStart(nil)
+	document = bsoncore.AppendObjectIDElement(document, "_id", id)
+	for _, element := range elements {
+		document = append(document, element...)
+	}
+	if document, err = bsoncore.AppendDocumentEnd(document, index); err != nil {
+		return nil, nil, fmt.Errorf("append _id: %w", err)
+	}
+
+	return document, id, nil
+}
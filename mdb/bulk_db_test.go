@@ -0,0 +1,116 @@
+//go:build database
+
+package mdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type bulkDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[SimpleItem]
+}
+
+func TestBulkDbSuite(t *testing.T) {
+	suite.Run(t, new(bulkDbTestSuite))
+}
+
+func (suite *bulkDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](
+		&suite.AccessTestSuite, testCollectionValidation,
+		NewIndexDescription(true, "alpha"))
+}
+
+func (suite *bulkDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *bulkDbTestSuite) TestCreateMany() {
+	items := make([]*SimpleItem, 0, 25)
+	for i := 0; i < 25; i++ {
+		items = append(items, &SimpleItem{
+			Alpha:   fmt.Sprintf("Alpha #%d", i),
+			Bravo:   i,
+			Charlie: "Bulk",
+		})
+	}
+	result, err := suite.typed.CreateMany(items)
+	suite.Require().NoError(err)
+	suite.Equal(int64(25), result.Inserted)
+	suite.Require().Len(result.Items, 25)
+	for _, item := range result.Items {
+		suite.NoError(item.Err)
+		suite.NotNil(item.InsertedID)
+	}
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(25), count)
+	found, err := suite.typed.Find(IDfilter(result.Items[7].InsertedID.(primitive.ObjectID)))
+	suite.Require().NoError(err)
+	suite.Equal("Alpha #7", found.Alpha)
+}
+
+func (suite *bulkDbTestSuite) TestCreateManyOrderedDuplicate() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	result, err := suite.typed.Bulk().ChunkSize(2).
+		Insert(SimpleItem1, SimpleItem2, SimpleItem3).
+		Execute()
+	suite.Require().ErrorIs(err, ErrBulkItemsFailed)
+	var opErr *OpError
+	suite.Require().ErrorAs(err, &opErr)
+	suite.Equal("bulk write", opErr.Op)
+	suite.Require().NotNil(result)
+	suite.Equal(int64(1), result.Inserted)
+	suite.Equal([]int{1}, result.Duplicates())
+	suite.NoError(result.Items[0].Err)
+	suite.ErrorIs(result.Items[2].Err, ErrBulkNotAttempted)
+	_, err = suite.typed.Find(SimpleItem3.Filter())
+	suite.True(IsNotFound(err))
+}
+
+func (suite *bulkDbTestSuite) TestCreateManyUnorderedDuplicate() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	result, err := suite.typed.CreateMany(
+		[]*SimpleItem{SimpleItem1, SimpleItem2, SimpleItem3},
+		options.BulkWrite().SetOrdered(false))
+	suite.Require().ErrorIs(err, ErrBulkItemsFailed)
+	suite.Require().NotNil(result)
+	suite.Equal(int64(2), result.Inserted)
+	suite.Equal([]int{1}, result.Duplicates())
+	suite.Len(result.Failed(), 1)
+	suite.NotNil(result.Items[2].InsertedID)
+	_, err = suite.typed.Find(SimpleItem3.Filter())
+	suite.NoError(err)
+}
+
+func (suite *bulkDbTestSuite) TestBulkWriter() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	result, err := suite.typed.Bulk().
+		Insert(SimpleItem2).
+		Update(SimpleItem1.Filter(), bson.M{"$inc": bson.M{"delta": 2}}, false).
+		Replace(SimpleItem2.Filter(), SimpleItem3, false).
+		Replace(SimpleItem1x.Filter(), SimpleItem1x, true).
+		Delete(SimpleItem1.Filter()).
+		Execute()
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.Inserted)
+	suite.Equal(int64(2), result.Matched)
+	suite.Equal(int64(2), result.Modified)
+	suite.Equal(int64(1), result.Upserted)
+	suite.Equal(int64(1), result.Deleted)
+	suite.NotNil(result.Items[3].UpsertedID)
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+	_, err = suite.typed.Find(SimpleItem3.Filter())
+	suite.NoError(err)
+	_, err = suite.typed.Find(SimpleItem1x.Filter())
+	suite.NoError(err)
+}
@@ -0,0 +1,70 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type bulkTestSuite struct {
+	suite.Suite
+}
+
+func TestBulkSuite(t *testing.T) {
+	suite.Run(t, new(bulkTestSuite))
+}
+
+func (suite *bulkTestSuite) TestDocumentWithNewID() {
+	document, id, err := documentWithID(SimpleItem1)
+	suite.Require().NoError(err)
+	oid, ok := id.(primitive.ObjectID)
+	suite.Require().True(ok)
+	suite.False(oid.IsZero())
+	elements, err := document.Elements()
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(elements)
+	suite.Equal("_id", elements[0].Key())
+	item := new(SimpleItem)
+	suite.Require().NoError(bson.Unmarshal(document, item))
+	suite.Equal(oid, item.ID())
+	suite.Equal(SimpleItem1.Alpha, item.Alpha)
+	suite.Equal(SimpleItem1.Charlie, item.Charlie)
+}
+
+func (suite *bulkTestSuite) TestDocumentWithExistingID() {
+	existing := primitive.NewObjectID()
+	item := &SimpleItem{Identity: Identity{ObjectID: existing}, Alpha: "existing"}
+	document, id, err := documentWithID(item)
+	suite.Require().NoError(err)
+	suite.Equal(existing, id)
+	decoded := new(SimpleItem)
+	suite.Require().NoError(bson.Unmarshal(document, decoded))
+	suite.Equal(existing, decoded.ID())
+}
+
+func (suite *bulkTestSuite) TestBulkResult() {
+	result := &BulkResult{Items: []BulkItemResult{
+		{Index: 0},
+		{Index: 1, Err: ErrBulkNotAttempted, Duplicate: true},
+		{Index: 2, Err: ErrBulkNotAttempted},
+	}}
+	suite.Len(result.Failed(), 2)
+	suite.Equal([]int{1}, result.Duplicates())
+}
+
+func (suite *bulkTestSuite) TestBuilder() {
+	typed := &TypedCollection[SimpleItem]{}
+	bulk := typed.Bulk().
+		Insert(SimpleItem1, SimpleItem2).
+		Update(SimpleItem1.Filter(), bson.M{"$inc": bson.M{"delta": 1}}, false).
+		Replace(SimpleItem2.Filter(), SimpleItem3, true).
+		Delete(SimpleItem3.Filter()).
+		ChunkSize(2).
+		Ordered(false)
+	suite.Equal(5, bulk.Len())
+	suite.Equal(2, bulk.chunkSize)
+	suite.False(bulk.ordered)
+	suite.Len(bulk.insertedIDs, 2)
+}
@@ -13,7 +13,8 @@ import (
 type Collection struct {
 	*Access
 	*mongo.Collection
-	ctx context.Context
+	ctx        context.Context
+	softDelete string
 }
 
 // ConnectCollection creates a new collection object with the specified collection definition.
@@ -31,17 +32,49 @@ func (c *Collection) ContextWithTimeout() (context.Context, context.CancelFunc)
 
 // Count documents in collection matching filter.
 func (c *Collection) Count(filter bson.D) (int64, error) {
-	if count, err := c.Collection.CountDocuments(context.TODO(), filter); err != nil {
-		return 0, fmt.Errorf("insert item: %w", err)
+	return c.CountWithOptions(c.ctx, filter)
+}
+
+// CountWithOptions counts documents in collection matching filter.
+// Options may be used to specify limit, skip, hint, collation, or maxTime.
+// Specify a limit when only a minimum count is needed to avoid counting every match.
+func (c *Collection) CountWithOptions(ctx context.Context, filter bson.D, opts ...*options.CountOptions) (int64, error) {
+	if count, err := c.Collection.CountDocuments(ctx, c.activeFilter(filter), opts...); err != nil {
+		return 0, c.opError("count", filter, err)
 	} else {
 		return count, nil
 	}
 }
 
+// EstimatedCount returns the approximate number of documents in the collection from collection metadata
+// without scanning the collection.
+// The estimate includes soft deleted items and may be inaccurate after an unclean shutdown.
+func (c *Collection) EstimatedCount(ctx context.Context) (int64, error) {
+	if count, err := c.Collection.EstimatedDocumentCount(ctx); err != nil {
+		return 0, c.opError("estimated count", nil, err)
+	} else {
+		return count, nil
+	}
+}
+
+// Exists returns true if any document in collection matches filter.
+// Only the _id of the first matching document is returned by the server.
+func (c *Collection) Exists(ctx context.Context, filter bson.D) (bool, error) {
+	err := c.FindOne(ctx, c.activeFilter(filter),
+		options.FindOne().SetProjection(bson.D{{Key: "_id", Value: 1}})).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	} else if err != nil {
+		return false, c.opError("exists", filter, err)
+	}
+
+	return true, nil
+}
+
 // Create item in DB.
 func (c *Collection) Create(item interface{}) error {
 	if _, err := c.InsertOne(c.ctx, item); err != nil {
-		return fmt.Errorf("insert item: %w", err)
+		return c.opError("create", nil, err)
 	}
 
 	return nil
@@ -49,44 +82,57 @@ func (c *Collection) Create(item interface{}) error {
 
 // Delete item from DB.
 // Set idempotent to true to avoid errors if the item does not exist.
+// If soft delete is configured the item is marked as deleted instead of being removed.
 func (c *Collection) Delete(filter bson.D, idempotent bool) error {
+	if c.SoftDeleted() {
+		result, err := c.UpdateOne(c.ctx, c.activeFilter(filter), c.softDeleteChanges())
+		if err != nil {
+			return c.opError("delete", filter, err)
+		}
+		if result.MatchedCount == 0 && !idempotent {
+			return c.opError("delete", filter, ErrNoItemMatch)
+		}
+		return nil
+	}
+
 	result, err := c.DeleteOne(c.ctx, filter)
 	if err != nil {
-		return fmt.Errorf("delete item: %w", err)
+		return c.opError("delete", filter, err)
 	}
-	if result.DeletedCount > 1 || (result.DeletedCount == 0 && !idempotent) {
+	if result.DeletedCount == 0 && !idempotent {
 		// Should have deleted a single item or none if idempotent flag set.
-		return fmt.Errorf("deleted %d items", result.DeletedCount)
+		return c.opError("delete", filter, ErrNoItemMatch)
 	}
 
 	return nil
 }
 
 // DeleteAll items from this collection.
+// If soft delete is configured the items are marked as deleted instead of being removed.
 func (c *Collection) DeleteAll() error {
-	_, err := c.DeleteMany(c.ctx, NoFilter())
-	if err != nil {
-		return fmt.Errorf("delete all: %w", err)
+	if c.SoftDeleted() {
+		_, err := c.UpdateMany(c.ctx, c.activeFilter(NoFilter()), c.softDeleteChanges())
+		return c.opError("delete all", nil, err)
 	}
-	return nil
+
+	_, err := c.DeleteMany(c.ctx, NoFilter())
+	return c.opError("delete all", nil, err)
 }
 
 // Drop collection.
 func (c *Collection) Drop() error {
 	ctx, cancelFn := c.ContextWithTimeout()
 	defer cancelFn()
-	return c.Collection.Drop(ctx)
+	return c.opError("drop", nil, c.Collection.Drop(ctx))
 }
 
 // Find an item in the database and return it as a blank interface.
 // The result will likely contain bson objects.
-func (c *Collection) Find(filter bson.D) (interface{}, error) {
+// Options may be used to specify sort, skip, projection, hint, collation, or maxTime.
+func (c *Collection) Find(filter bson.D, opts ...*options.FindOneOptions) (interface{}, error) {
 	var item interface{}
-	if err := c.FindOne(c.ctx, filter).Decode(&item); err != nil {
-		if IsNotFound(err) {
-			return nil, fmt.Errorf("no item '%v': %w", filter, err)
-		}
-		return nil, fmt.Errorf("find item '%v': %w", filter, err)
+	if err := c.FindOne(c.ctx, c.activeFilter(filter), opts...).Decode(&item); err != nil {
+		return nil, c.opError("find", filter, err)
 	}
 
 	return item, nil
@@ -97,8 +143,8 @@ func (c *Collection) Find(filter bson.D) (interface{}, error) {
 func (c *Collection) FindOrCreate(filter bson.D, item interface{}) (interface{}, error) {
 	upsert := true
 	if err := c.Update(filter, bson.M{"$setOnInsert": item}, &options.UpdateOptions{Upsert: &upsert}); err != nil {
-		if !errors.Is(err, errNoItemModified) { // OK if item already exists.
-			return nil, fmt.Errorf("update $setOnInsert: %w", err)
+		if !errors.Is(err, ErrNoItemModified) { // OK if item already exists.
+			return nil, err
 		}
 	}
 	return c.Find(filter)
@@ -106,39 +152,42 @@ func (c *Collection) FindOrCreate(filter bson.D, item interface{}) (interface{},
 
 // Iterate over a set of items, applying the specified function to each one.
 // The items passed to the function will likely contain bson objects.
-func (c *Collection) Iterate(filter bson.D, fn func(item interface{}) error) error {
-	if cursor, err := c.Collection.Find(c.ctx, filter); err != nil {
-		return fmt.Errorf("find items: %w", err)
-	} else {
-		var item interface{}
-		for cursor.Next(c.ctx) {
-			if err := cursor.Decode(&item); err != nil {
-				return fmt.Errorf("decode item: %w", err)
-			} else if err := fn(item); err != nil {
-				return fmt.Errorf("apply function: %w", err)
-			}
-		}
+// Options may be used to specify sort, limit, skip, projection, hint, collation, maxTime, or batch size.
+// Return StopIteration from the function to end iteration early without an error.
+func (c *Collection) Iterate(filter bson.D, fn func(item interface{}) error, opts ...*options.FindOptions) error {
+	cursor, err := c.Collection.Find(c.ctx, c.activeFilter(filter), opts...)
+	if err != nil {
+		return c.opError("iterate", filter, err)
 	}
 
-	return nil
+	return c.opError("iterate", filter, iterateCursor(c.ctx, cursor, func(cursor *mongo.Cursor) error {
+		var item interface{}
+		if err := cursor.Decode(&item); err != nil {
+			return fmt.Errorf("decode item: %w", err)
+		} else if err := fn(item); err != nil {
+			return fmt.Errorf("apply function: %w", err)
+		}
+		return nil
+	}))
 }
 
 // Replace entire item referenced by filter with specified item.
+// The item fields are set with $set so fields not present in the item are kept,
+// use ReplaceDocument to replace the entire document.
 // If the filter matches more than one document mongo-go-driver will choose one to update.
 func (c *Collection) Replace(filter, item interface{}, opts ...*options.UpdateOptions) error {
 	return c.Update(filter, bson.M{"$set": item}, opts...)
 }
 
-var errNotString = errors.New("value not a string")
-
 // StringValuesFor returns an array of distinct string values for the specified filter and field.
+// Use DistinctValues for fields of other types or to specify a context.
 func (c *Collection) StringValuesFor(field string, filter bson.D) ([]string, error) {
 	if filter == nil {
 		filter = NoFilter()
 	}
-	values, err := c.Distinct(c.Context(), field, filter)
+	values, err := c.Distinct(c.Context(), field, c.activeFilter(filter))
 	if err != nil {
-		return nil, fmt.Errorf("distinct values: %w", err)
+		return nil, c.opError("distinct", filter, err)
 	}
 
 	var ok bool
@@ -146,27 +195,24 @@ func (c *Collection) StringValuesFor(field string, filter bson.D) ([]string, err
 	result := make([]string, length)
 	for i := 0; i < length; i++ {
 		if result[i], ok = values[i].(string); !ok {
-			return nil, errNotString
+			return nil, c.opError("distinct", filter, fmt.Errorf("field %s: %w", field, ErrNotString))
 		}
 	}
 
 	return result, nil
 }
 
-var errNoItemMatch = errors.New("no matching item")
-var errNoItemModified = errors.New("no modified item")
-
 // Update item referenced by filter by applying update operator expressions.
 // If the filter matches more than one document mongo-go-driver will choose one to update.
 func (c *Collection) Update(filter, changes interface{}, opts ...*options.UpdateOptions) error {
-	result, err := c.UpdateOne(c.Context(), filter, changes, opts...)
+	result, err := c.UpdateOne(c.Context(), c.activeFilter(filter), changes, opts...)
 	if err != nil {
-		return fmt.Errorf("replace item: %w", err)
+		return c.opError("update", filter, err)
 	} else if result.MatchedCount < 1 && result.UpsertedCount < 1 {
-		return errNoItemMatch
+		return c.opError("update", filter, ErrNoItemMatch)
 	} else if result.ModifiedCount < 1 && result.UpsertedCount < 1 {
 		// Not sure how to test this,  may never happen.
-		return errNoItemModified
+		return c.opError("update", filter, ErrNoItemModified)
 	} else {
 		return nil
 	}
@@ -174,6 +220,35 @@ func (c *Collection) Update(filter, changes interface{}, opts ...*options.Update
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// StopIteration may be returned from an Iterate function to end iteration early.
+// It is not reported as an error by Iterate.
+var StopIteration = errors.New("stop iteration")
+
+// iterateCursor applies the function to each document in the cursor.
+// The cursor is always closed and any cursor error is returned.
+// A function result of StopIteration ends iteration without error.
+func iterateCursor(ctx context.Context, cursor *mongo.Cursor, fn func(cursor *mongo.Cursor) error) (err error) {
+	defer func() {
+		if closeErr := cursor.Close(context.Background()); closeErr != nil && err == nil {
+			err = fmt.Errorf("close cursor: %w", closeErr)
+		}
+	}()
+
+	for cursor.Next(ctx) {
+		if err = fn(cursor); err != nil {
+			if errors.Is(err, StopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		return fmt.Errorf("iterate cursor: %w", err)
+	}
+
+	return nil
+}
+
 // NoFilter returns an empty bson.D object for use as an empty filter.
 func NoFilter() bson.D {
 	return bson.D{}
@@ -3,6 +3,7 @@
 package mdb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type collectionTestSuite struct {
@@ -130,7 +132,7 @@ func (suite *collectionTestSuite) TestCreateFindDelete() {
 	suite.True(IsNotFound(err))
 	suite.Nil(noItem)
 	err = suite.collection.Delete(SimpleItem2.Filter(), false)
-	suite.Require().Error(err)
+	suite.Require().ErrorIs(err, ErrNoItemMatch)
 	err = suite.collection.Delete(SimpleItem2.Filter(), true)
 	suite.Require().NoError(err)
 }
@@ -148,6 +150,39 @@ func (suite *collectionTestSuite) TestCountDeleteAll() {
 	suite.Equal(int64(0), count)
 }
 
+func (suite *collectionTestSuite) TestCountWithOptions() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	suite.Require().NoError(suite.collection.Create(SimpleItem3))
+	count, err := suite.collection.CountWithOptions(context.Background(), NoFilter(), options.Count().SetLimit(2))
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+	count, err = suite.collection.CountWithOptions(context.Background(), NoFilter(), options.Count().SetSkip(1))
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+	count, err = suite.collection.CountWithOptions(context.Background(), SimpleItem1.Filter())
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *collectionTestSuite) TestEstimatedCount() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	count, err := suite.collection.EstimatedCount(context.Background())
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *collectionTestSuite) TestExists() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	exists, err := suite.collection.Exists(context.Background(), SimpleItem1.Filter())
+	suite.NoError(err)
+	suite.True(exists)
+	exists, err = suite.collection.Exists(context.Background(), SimpleItem2.Filter())
+	suite.NoError(err)
+	suite.False(exists)
+}
+
 func (suite *collectionTestSuite) TestIterate() {
 	suite.Require().NoError(suite.collection.Create(SimpleItem1))
 	suite.Require().NoError(suite.collection.Create(SimpleItem2))
@@ -190,6 +225,55 @@ func (suite *collectionTestSuite) TestIterateFiltered() {
 	suite.Equal([]string{"one"}, alpha)
 }
 
+func (suite *collectionTestSuite) TestIterateStop() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	suite.Require().NoError(suite.collection.Create(SimpleItem3))
+	count := 0
+	suite.NoError(suite.collection.Iterate(NoFilter(),
+		func(item interface{}) error {
+			count++
+			if count == 2 {
+				return StopIteration
+			}
+			return nil
+		}))
+	suite.Equal(2, count)
+}
+
+func (suite *collectionTestSuite) TestIterateOptions() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	suite.Require().NoError(suite.collection.Create(SimpleItem3))
+	var alpha []string
+	suite.NoError(suite.collection.Iterate(NoFilter(),
+		func(item interface{}) error {
+			if bd, ok := item.(bson.D); ok {
+				m := bd.Map()
+				suite.NotContains(m, "charlie")
+				if a, ok := m["alpha"].(string); ok {
+					alpha = append(alpha, a)
+				}
+			}
+			return nil
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "alpha", Value: 1}}).
+			SetLimit(2).
+			SetProjection(bson.D{{Key: "charlie", Value: 0}})))
+	suite.Equal([]string{"one", "three"}, alpha)
+}
+
+func (suite *collectionTestSuite) TestFindOptions() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	item, err := suite.collection.Find(NoFilter(), options.FindOne().
+		SetSort(bson.D{{Key: "bravo", Value: -1}}).
+		SetSkip(1))
+	suite.Require().NoError(err)
+	suite.bsonFieldEquals(item, "alpha", "one")
+}
+
 func (suite *collectionTestSuite) TestReplace() {
 	suite.Require().NoError(suite.collection.Create(SimpleItem1))
 	item, err := suite.collection.Find(SimpleItem1.Filter())
@@ -207,14 +291,14 @@ func (suite *collectionTestSuite) TestReplace() {
 	suite.NotNil(suite.bsonGetID(item))
 	// Replace with same value:
 	err = suite.collection.Replace(SimpleItem1x.Filter(), SimpleItem1x)
-	suite.Require().ErrorIs(err, errNoItemModified)
+	suite.Require().ErrorIs(err, ErrNoItemModified)
 	item, err = suite.collection.Find(SimpleItem1x.Filter())
 	suite.Require().NoError(err)
 	suite.bsonFieldEquals(item, "alpha", "xRay")
 	// No match for filter:
 	item, err = suite.collection.Find(SimpleItem3.Filter())
 	suite.True(IsNotFound(err))
-	suite.ErrorIs(suite.collection.Replace(SimpleItem3.Filter(), SimpleItem3), errNoItemMatch)
+	suite.ErrorIs(suite.collection.Replace(SimpleItem3.Filter(), SimpleItem3), ErrNoItemMatch)
 	// Upsert new item:
 	suite.NoError(suite.collection.Replace(NoFilter(), SimpleItem3))
 	item, err = suite.collection.Find(SimpleItem3.Filter())
@@ -223,6 +307,40 @@ func (suite *collectionTestSuite) TestReplace() {
 	suite.NotNil(suite.bsonGetID(item))
 }
 
+func (suite *collectionTestSuite) TestReplaceDocument() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	item, err := suite.collection.Find(SimpleItem1.Filter())
+	suite.Require().NoError(err)
+	suite.bsonFieldEquals(item, "delta", int32(1))
+	id := suite.bsonGetID(item)
+	// Replace with item without delta field:
+	result, err := suite.collection.ReplaceDocument(SimpleItem1.Filter(), SimpleItem2, false)
+	suite.Require().NoError(err)
+	suite.Equal(ItemReplaced, result.Status)
+	suite.Nil(result.UpsertedID)
+	item, err = suite.collection.Find(SimpleItem2.Filter())
+	suite.Require().NoError(err)
+	suite.bsonFieldEquals(item, "alpha", "two")
+	suite.Equal(id, suite.bsonGetID(item))
+	_, found := item.(bson.D).Map()["delta"]
+	suite.False(found, "field removed")
+	// Replace with same value:
+	result, err = suite.collection.ReplaceDocument(SimpleItem2.Filter(), SimpleItem2, false)
+	suite.Require().NoError(err)
+	suite.Equal(ItemUnchanged, result.Status)
+	// No match for filter:
+	_, err = suite.collection.ReplaceDocument(SimpleItem3.Filter(), SimpleItem3, false)
+	suite.ErrorIs(err, ErrNoItemMatch)
+	// Upsert new item:
+	result, err = suite.collection.ReplaceDocument(SimpleItem3.Filter(), SimpleItem3, true)
+	suite.Require().NoError(err)
+	suite.Equal(ItemCreated, result.Status)
+	suite.NotNil(result.UpsertedID)
+	item, err = suite.collection.Find(SimpleItem3.Filter())
+	suite.Require().NoError(err)
+	suite.Equal(result.UpsertedID, suite.bsonGetID(item))
+}
+
 func (suite *collectionTestSuite) TestUpdate() {
 	suite.Require().NoError(suite.collection.Create(SimpleItem1))
 	item, err := suite.collection.Find(SimpleItem1.Filter())
@@ -247,7 +365,7 @@ func (suite *collectionTestSuite) TestUpdate() {
 	suite.ErrorIs(suite.collection.Update(SimpleItem3.Filter(), bson.M{
 		"$set": bson.M{"charlie": "Horse"},
 		"$inc": bson.M{"delta": 7},
-	}), errNoItemMatch)
+	}), ErrNoItemMatch)
 }
 
 func (suite *collectionTestSuite) TestStringValuesFor() {
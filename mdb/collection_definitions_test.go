@@ -14,4 +14,35 @@ var (
 	testCollectionWrapped = &CollectionDefinition{
 		Name: "test-collection-wrapped",
 	}
+	testCollectionMerged = &CollectionDefinition{
+		Name: "test-collection-merged",
+	}
+	testCollectionSoftDelete = &CollectionDefinition{
+		Name:       "test-collection-soft-delete",
+		SoftDelete: true,
+	}
+	testCollectionVersioned = &CollectionDefinition{
+		Name: "test-collection-versioned",
+	}
+	testCollectionTimestamps = &CollectionDefinition{
+		Name: "test-collection-timestamps",
+	}
+	testCollectionWatched = &CollectionDefinition{
+		Name: "test-collection-watched",
+	}
+	testCollectionResumeTokens = &CollectionDefinition{
+		Name: "test-collection-resume-tokens",
+	}
+	testCollectionSearch = &CollectionDefinition{
+		Name: "test-collection-search",
+	}
+	testCollectionGeo = &CollectionDefinition{
+		Name: "test-collection-geo",
+	}
+	testCollectionImport = &CollectionDefinition{
+		Name: "test-collection-import",
+	}
+	testCollectionHooks = &CollectionDefinition{
+		Name: "test-collection-hooks",
+	}
 )
@@ -0,0 +1,81 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DistinctValues returns the distinct values of the field for items matching the filter.
+// Each value is decoded into the specified type, which may be any type that can be decoded from BSON,
+// for example a number, primitive.ObjectID, time.Time, or struct.
+// As with the distinct command array fields contribute each of their elements.
+// Use the Collection embedded in a TypedCollection to get values from a typed collection.
+func DistinctValues[V any](collection *Collection, ctx context.Context, field string, filter bson.D) ([]V, error) {
+	if filter == nil {
+		filter = NoFilter()
+	}
+	values, err := collection.Distinct(ctx, field, collection.activeFilter(filter))
+	if err != nil {
+		return nil, collection.opError("distinct", filter, err)
+	}
+
+	result := make([]V, len(values))
+	for i, value := range values {
+		if result[i], err = decodeValue[V](value); err != nil {
+			return nil, collection.opError("distinct", filter, fmt.Errorf("field %s: %w", field, err))
+		}
+	}
+
+	return result, nil
+}
+
+// ValueCount is a distinct value and the number of items with that value.
+type ValueCount[V any] struct {
+	Value V     `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+// DistinctCounts returns the distinct values of the field for items matching the filter
+// with the number of items having each value, ordered by descending count and then value.
+// Counts are computed with an aggregation pipeline,
+// which is useful for showing the number of matches next to each choice of a faceted filter.
+// Array fields contribute each of their elements,
+// items where the field is missing, null, or an empty array are not counted.
+func DistinctCounts[V any](collection *Collection, ctx context.Context, field string, filter bson.D) ([]ValueCount[V], error) {
+	if filter == nil {
+		filter = NoFilter()
+	}
+	pipeline := NewPipeline().
+		Match(collection.activeFilter(filter)).
+		Unwind(field, false).
+		Group(fieldPath(field), bson.D{{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}).
+		Sort(bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, collection.opError("distinct counts", filter, fmt.Errorf("field %s: %w", field, err))
+	}
+
+	counts := make([]ValueCount[V], 0)
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, collection.opError("distinct counts", filter, fmt.Errorf("field %s: %w", field, err))
+	}
+
+	return counts, nil
+}
+
+// decodeValue decodes a single value returned by mongo-go-driver into the specified type.
+func decodeValue[V any](value interface{}) (V, error) {
+	var holder struct {
+		Value V `bson:"value"`
+	}
+	raw, err := bson.Marshal(bson.D{{Key: "value", Value: value}})
+	if err != nil {
+		return holder.Value, fmt.Errorf("marshal value: %w", err)
+	}
+	if err = bson.Unmarshal(raw, &holder); err != nil {
+		return holder.Value, fmt.Errorf("decode value: %w", err)
+	}
+	return holder.Value, nil
+}
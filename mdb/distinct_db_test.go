@@ -0,0 +1,87 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type distinctDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[SimpleItem]
+}
+
+func TestDistinctDbSuite(t *testing.T) {
+	suite.Run(t, new(distinctDbTestSuite))
+}
+
+func (suite *distinctDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollection)
+}
+
+func (suite *distinctDbTestSuite) SetupTest() {
+	for i := 0; i < 10; i++ {
+		suite.Require().NoError(suite.typed.Create(&SimpleItem{
+			Alpha:   fmt.Sprintf("Alpha #%d", i),
+			Bravo:   i % 3,
+			Charlie: []string{"even", "odd"}[i%2],
+		}))
+	}
+}
+
+func (suite *distinctDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *distinctDbTestSuite) TestDistinctValues() {
+	values, err := DistinctValues[int](&suite.typed.Collection, context.Background(), "bravo", nil)
+	suite.Require().NoError(err)
+	suite.ElementsMatch([]int{0, 1, 2}, values)
+	values, err = DistinctValues[int](&suite.typed.Collection, context.Background(), "bravo",
+		bson.D{{Key: "charlie", Value: "odd"}})
+	suite.Require().NoError(err)
+	suite.ElementsMatch([]int{0, 1, 2}, values)
+	strings, err := DistinctValues[string](&suite.typed.Collection, context.Background(), "charlie", nil)
+	suite.Require().NoError(err)
+	suite.ElementsMatch([]string{"even", "odd"}, strings)
+	values, err = DistinctValues[int](&suite.typed.Collection, context.Background(), "goober", nil)
+	suite.Require().NoError(err)
+	suite.Empty(values)
+}
+
+func (suite *distinctDbTestSuite) TestDistinctValuesWrongType() {
+	_, err := DistinctValues[int](&suite.typed.Collection, context.Background(), "charlie", nil)
+	suite.Error(err)
+}
+
+func (suite *distinctDbTestSuite) TestDistinctCounts() {
+	counts, err := DistinctCounts[int](&suite.typed.Collection, context.Background(), "bravo", nil)
+	suite.Require().NoError(err)
+	suite.Equal([]ValueCount[int]{
+		{Value: 0, Count: 4},
+		{Value: 1, Count: 3},
+		{Value: 2, Count: 3},
+	}, counts)
+	charlies, err := DistinctCounts[string](&suite.typed.Collection, context.Background(), "charlie",
+		bson.D{{Key: "bravo", Value: 0}})
+	suite.Require().NoError(err)
+	suite.Equal([]ValueCount[string]{
+		{Value: "even", Count: 2},
+		{Value: "odd", Count: 2},
+	}, charlies)
+}
+
+func (suite *distinctDbTestSuite) TestDistinctCountsWrongType() {
+	_, err := DistinctCounts[int](&suite.typed.Collection, context.Background(), "charlie", nil)
+	var opErr *OpError
+	suite.Require().ErrorAs(err, &opErr)
+	suite.Equal("distinct counts", opErr.Op)
+	suite.False(errors.As(opErr.Err, &opErr), "single OpError")
+}
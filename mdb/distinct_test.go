@@ -0,0 +1,47 @@
+package mdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type distinctTestSuite struct {
+	suite.Suite
+}
+
+func TestDistinctSuite(t *testing.T) {
+	suite.Run(t, new(distinctTestSuite))
+}
+
+func (suite *distinctTestSuite) TestDecodeValue() {
+	number, err := decodeValue[int](int32(17))
+	suite.Require().NoError(err)
+	suite.Equal(17, number)
+
+	id := primitive.NewObjectID()
+	decodedID, err := decodeValue[primitive.ObjectID](id)
+	suite.Require().NoError(err)
+	suite.Equal(id, decodedID)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	decodedTime, err := decodeValue[time.Time](primitive.NewDateTimeFromTime(now))
+	suite.Require().NoError(err)
+	suite.True(now.Equal(decodedTime))
+
+	type point struct {
+		X int `bson:"x"`
+		Y int `bson:"y"`
+	}
+	decodedPoint, err := decodeValue[point](bson.D{{Key: "x", Value: 1}, {Key: "y", Value: 2}})
+	suite.Require().NoError(err)
+	suite.Equal(point{X: 1, Y: 2}, decodedPoint)
+}
+
+func (suite *distinctTestSuite) TestDecodeValueWrongType() {
+	_, err := decodeValue[int]("seventeen")
+	suite.Error(err)
+}
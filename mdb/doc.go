@@ -27,4 +27,5 @@
 // when using 'go test -tags database', without this tag only unit tests are run.
 //
 // The IndexTester supports verifying that a specific index has been added to a collection.
+// The PlanTester supports verifying that a query uses a specific index.
 package mdb
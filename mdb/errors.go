@@ -0,0 +1,206 @@
+package mdb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+var (
+	// ErrNoItemMatch is returned when an update or delete matches no item.
+	ErrNoItemMatch = errors.New("no matching item")
+
+	// ErrNoItemModified is returned when an update matches an item but doesn't change it.
+	ErrNoItemModified = errors.New("no modified item")
+
+	// ErrNotString is returned by StringValuesFor when a value is not a string.
+	ErrNotString = errors.New("value not a string")
+)
+
+// OpError is returned by Collection and TypedCollection operations.
+// Use errors.As to retrieve it or errors.Is to check its cause against sentinel errors.
+type OpError struct {
+	// Op is the operation such as "find", "create", or "update".
+	Op string
+
+	// Collection is the name of the collection.
+	Collection string
+
+	// Filter is the filter used by the operation, nil if there was none.
+	Filter interface{}
+
+	// Err is the underlying cause.
+	Err error
+}
+
+func (e *OpError) Error() string {
+	if e.Filter == nil {
+		return fmt.Sprintf("%s %s: %v", e.Op, e.Collection, e.Err)
+	}
+	return fmt.Sprintf("%s %s '%v': %v", e.Op, e.Collection, e.Filter, e.Err)
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// opError returns an OpError for the collection or nil if there is no error.
+func (c *Collection) opError(op string, filter interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &OpError{Op: op, Collection: c.Collection.Name(), Filter: filter, Err: err}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Functions to check for specific, known errors.
+
+const (
+	// duplicateKeyCode is the server error code for a duplicate key.
+	duplicateKeyCode = 11000
+
+	// validationFailureCode is the server error code for a document validation failure.
+	validationFailureCode = 121
+)
+
+// IsDuplicate checks to see if the specified error is for attempting to create a duplicate document.
+func IsDuplicate(err error) bool {
+	_, ok := DuplicateKey(err)
+	return ok
+}
+
+// DuplicateKeyInfo describes the index and key values of a duplicate key error.
+// Fields the server doesn't report are left empty.
+type DuplicateKeyInfo struct {
+	// Index is the name of the unique index.
+	Index string
+
+	// KeyPattern is the key specification of the unique index.
+	KeyPattern bson.D
+
+	// KeyValue contains the duplicated values of the index keys.
+	KeyValue bson.D
+}
+
+var duplicateIndexPattern = regexp.MustCompile(`index: (\S+) dup key`)
+
+// DuplicateKey returns details of a duplicate key error
+// from an insert, update, bulk write, or find and modify operation.
+// The second result is false if the error is not for a duplicate key.
+func DuplicateKey(err error) (*DuplicateKeyInfo, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var we mongo.WriteException
+	var bwe mongo.BulkWriteException
+	var bwErr mongo.BulkWriteError
+	var wErr mongo.WriteError
+	var ce mongo.CommandError
+	if errors.As(err, &we) {
+		for _, writeErr := range we.WriteErrors {
+			if writeErr.Code == duplicateKeyCode {
+				return duplicateKeyInfo(writeErr.Message, writeErr.Raw), true
+			}
+		}
+	} else if errors.As(err, &bwe) {
+		for _, writeErr := range bwe.WriteErrors {
+			if writeErr.Code == duplicateKeyCode {
+				return duplicateKeyInfo(writeErr.Message, writeErr.Raw), true
+			}
+		}
+	} else if errors.As(err, &bwErr) {
+		if bwErr.Code == duplicateKeyCode {
+			return duplicateKeyInfo(bwErr.Message, bwErr.Raw), true
+		}
+	} else if errors.As(err, &wErr) {
+		if wErr.Code == duplicateKeyCode {
+			return duplicateKeyInfo(wErr.Message, wErr.Raw), true
+		}
+	} else if errors.As(err, &ce) {
+		if ce.Code == duplicateKeyCode {
+			return duplicateKeyInfo(ce.Message, ce.Raw), true
+		}
+	}
+
+	return nil, false
+}
+
+// duplicateKeyInfo gets the index name from the error message and the keys from the raw server error.
+func duplicateKeyInfo(message string, raw bson.Raw) *DuplicateKeyInfo {
+	info := &DuplicateKeyInfo{}
+	if match := duplicateIndexPattern.FindStringSubmatch(message); match != nil {
+		info.Index = match[1]
+	}
+	if raw != nil {
+		if doc, ok := raw.Lookup("keyPattern").DocumentOK(); ok {
+			_ = bson.Unmarshal(doc, &info.KeyPattern)
+		}
+		if doc, ok := raw.Lookup("keyValue").DocumentOK(); ok {
+			_ = bson.Unmarshal(doc, &info.KeyValue)
+		}
+	}
+	return info
+}
+
+// IsNotFound checks an error condition to see if it matches the underlying database "not found" error
+// or the GridFS file not found error.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, gridfs.ErrFileNotFound)
+}
+
+// IsValidationFailure checks to see if the specified error is for a validation failure.
+func IsValidationFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var e mongo.WriteException
+	if errors.As(err, &e) {
+		for _, we := range e.WriteErrors {
+			if we.Code == validationFailureCode {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsTimeout checks to see if the specified error is from a timeout,
+// including context deadlines, server selection timeouts, and server maxTimeMS limits.
+func IsTimeout(err error) bool {
+	return mongo.IsTimeout(err)
+}
+
+// IsNetworkError checks to see if the specified error is from a network failure.
+func IsNetworkError(err error) bool {
+	return mongo.IsNetworkError(err)
+}
+
+// IsRetryable checks to see if the operation that returned the specified error may succeed if retried.
+// This is true for network errors and errors the server labels as retryable or transient.
+// Note that the driver already retries most operations once by default.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsNetworkError(err) {
+		return true
+	}
+
+	var le mongo.LabeledError
+	if errors.As(err, &le) {
+		return le.HasErrorLabel("RetryableWriteError") || le.HasErrorLabel("TransientTransactionError")
+	}
+
+	return false
+}
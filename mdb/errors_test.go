@@ -0,0 +1,123 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+type errorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(errorsTestSuite))
+}
+
+func (suite *errorsTestSuite) TestOpError() {
+	filter := bson.D{{Key: "alpha", Value: "one"}}
+	var err error = &OpError{Op: "update", Collection: "things", Filter: filter, Err: ErrNoItemMatch}
+	suite.ErrorIs(err, ErrNoItemMatch)
+	suite.Equal("update things '[{alpha one}]': no matching item", err.Error())
+	err = &OpError{Op: "create", Collection: "things", Err: ErrNoItemModified}
+	suite.Equal("create things: no modified item", err.Error())
+	wrapped := fmt.Errorf("outer: %w", err)
+	var opErr *OpError
+	suite.Require().ErrorAs(wrapped, &opErr)
+	suite.Equal("create", opErr.Op)
+	suite.Equal("things", opErr.Collection)
+	suite.Nil(opErr.Filter)
+}
+
+func (suite *errorsTestSuite) TestDuplicateKey() {
+	raw, err := bson.Marshal(bson.D{
+		{Key: "code", Value: duplicateKeyCode},
+		{Key: "keyPattern", Value: bson.D{{Key: "alpha", Value: 1}}},
+		{Key: "keyValue", Value: bson.D{{Key: "alpha", Value: "one"}}},
+	})
+	suite.Require().NoError(err)
+	message := `E11000 duplicate key error collection: db.things index: alpha_1 dup key: { alpha: "one" }`
+	expected := &DuplicateKeyInfo{
+		Index:      "alpha_1",
+		KeyPattern: bson.D{{Key: "alpha", Value: int32(1)}},
+		KeyValue:   bson.D{{Key: "alpha", Value: "one"}},
+	}
+	writeErr := mongo.WriteError{Code: duplicateKeyCode, Message: message, Raw: raw}
+	for _, err := range []error{
+		mongo.WriteException{WriteErrors: mongo.WriteErrors{writeErr}},
+		mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: writeErr}}},
+		mongo.BulkWriteError{WriteError: writeErr},
+		mongo.CommandError{Code: duplicateKeyCode, Message: message, Raw: raw},
+		&OpError{Op: "create", Collection: "things", Err: mongo.WriteException{WriteErrors: mongo.WriteErrors{writeErr}}},
+	} {
+		info, ok := DuplicateKey(err)
+		suite.Require().True(ok, err.Error())
+		suite.Equal(expected, info)
+		suite.True(IsDuplicate(err))
+	}
+}
+
+func (suite *errorsTestSuite) TestDuplicateKeyPartial() {
+	info, ok := DuplicateKey(mongo.WriteException{WriteErrors: mongo.WriteErrors{
+		{Code: duplicateKeyCode, Message: "E11000 duplicate key error"},
+	}})
+	suite.Require().True(ok)
+	suite.Equal(&DuplicateKeyInfo{}, info)
+}
+
+func (suite *errorsTestSuite) TestNotDuplicate() {
+	for _, err := range []error{
+		nil,
+		errors.New("other"),
+		mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: validationFailureCode}}},
+		mongo.CommandError{Code: 2},
+	} {
+		info, ok := DuplicateKey(err)
+		suite.False(ok)
+		suite.Nil(info)
+		suite.False(IsDuplicate(err))
+	}
+}
+
+func (suite *errorsTestSuite) TestNotFound() {
+	suite.True(IsNotFound(&OpError{Op: "find", Collection: "things", Err: mongo.ErrNoDocuments}))
+	suite.True(IsNotFound(&OpError{Op: "download", Collection: "blobs.files", Err: gridfs.ErrFileNotFound}))
+	suite.False(IsNotFound(ErrNoItemMatch))
+	suite.False(IsNotFound(nil))
+}
+
+func (suite *errorsTestSuite) TestValidationFailure() {
+	err := &OpError{Op: "create", Collection: "things",
+		Err: mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: validationFailureCode}}}}
+	suite.True(IsValidationFailure(err))
+	suite.False(IsValidationFailure(nil))
+	suite.False(IsValidationFailure(ErrNoItemMatch))
+}
+
+func (suite *errorsTestSuite) TestTimeout() {
+	suite.True(IsTimeout(&OpError{Op: "find", Collection: "things", Err: context.DeadlineExceeded}))
+	suite.False(IsTimeout(ErrNoItemMatch))
+	suite.False(IsTimeout(nil))
+}
+
+func (suite *errorsTestSuite) TestNetworkError() {
+	network := mongo.CommandError{Code: 6, Labels: []string{"NetworkError"}}
+	suite.True(IsNetworkError(&OpError{Op: "find", Collection: "things", Err: network}))
+	suite.True(IsRetryable(network))
+	suite.False(IsNetworkError(mongo.CommandError{Code: 6}))
+}
+
+func (suite *errorsTestSuite) TestRetryable() {
+	suite.True(IsRetryable(mongo.CommandError{Code: 91, Labels: []string{"RetryableWriteError"}}))
+	suite.True(IsRetryable(fmt.Errorf("outer: %w",
+		mongo.CommandError{Code: 112, Labels: []string{"TransientTransactionError"}})))
+	suite.False(IsRetryable(mongo.CommandError{Code: 2}))
+	suite.False(IsRetryable(ErrNoItemMatch))
+	suite.False(IsRetryable(nil))
+}
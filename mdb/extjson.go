@@ -0,0 +1,240 @@
+package mdb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxExtJSONLine is the longest line ImportExtJSON will read,
+// large enough for the Extended JSON form of a maximum size document.
+const maxExtJSONLine = 64 * 1024 * 1024
+
+// ExportExtJSON writes the documents matching the filter to the writer
+// as newline-delimited Extended JSON, one document per line, returning the number of documents written.
+// Canonical format preserves all BSON types exactly,
+// relaxed format is easier to read but numbers and dates may lose type information.
+// Options may be used to specify sort, projection, or batch size.
+func (c *Collection) ExportExtJSON(
+	ctx context.Context, writer io.Writer, filter bson.D, canonical bool, opts ...*options.FindOptions) (int64, error) {
+	if filter == nil {
+		filter = NoFilter()
+	}
+	cursor, err := c.Collection.Find(ctx, c.activeFilter(filter), opts...)
+	if err != nil {
+		return 0, c.opError("export", filter, err)
+	}
+
+	buffered := bufio.NewWriter(writer)
+	var count int64
+	err = iterateCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		line, err := bson.MarshalExtJSON(cursor.Current, canonical, false)
+		if err != nil {
+			return fmt.Errorf("marshal document %d: %w", count, err)
+		}
+		if _, err = buffered.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("write document %d: %w", count, err)
+		}
+		count++
+		return nil
+	})
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		return count, c.opError("export", filter, err)
+	}
+
+	return count, nil
+}
+
+// ImportOptions configures ImportExtJSON.
+type ImportOptions struct {
+	// BatchSize is the maximum number of documents sent to the server at once, default DefaultBulkChunkSize.
+	BatchSize int
+
+	// UpsertKey lists top level fields that identify a document.
+	// If set, each document replaces the document with the same key values or is inserted if there is none,
+	// otherwise documents are inserted.
+	UpsertKey []string
+
+	// SkipErrors continues past lines that can't be parsed or written,
+	// recording them in the ImportResult instead of returning an error.
+	SkipErrors bool
+}
+
+// ImportError describes a line that could not be imported.
+type ImportError struct {
+	// Line number starting at 1.
+	Line int
+	Err  error
+}
+
+func (ie *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %v", ie.Line, ie.Err)
+}
+
+func (ie *ImportError) Unwrap() error {
+	return ie.Err
+}
+
+// ImportResult contains counts and skipped lines for ImportExtJSON.
+type ImportResult struct {
+	Inserted int64
+	Upserted int64
+	Modified int64
+
+	// Skipped lines when SkipErrors is set.
+	Skipped []*ImportError
+}
+
+// ImportExtJSON reads newline-delimited Extended JSON documents, in canonical or relaxed format,
+// and writes them to the collection using bulk operations.
+// Blank lines are ignored.
+// Unless SkipErrors is set the first failed line is returned as an *ImportError,
+// documents in earlier batches will already have been written.
+// The result contains counts of documents written even when an error is returned.
+func (c *Collection) ImportExtJSON(ctx context.Context, reader io.Reader, opts ...*ImportOptions) (*ImportResult, error) {
+	opt := &ImportOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	batchSize := opt.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultBulkChunkSize
+	}
+
+	result := &ImportResult{}
+	importer := &extJSONImporter{
+		collection: c,
+		options:    opt,
+		result:     result,
+		models:     make([]mongo.WriteModel, 0, batchSize),
+		lines:      make([]int, 0, batchSize),
+	}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxExtJSONLine)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := importer.add(lineNumber, line); err != nil {
+			return result, c.opError("import", nil, err)
+		}
+		if len(importer.models) >= batchSize {
+			if err := importer.flush(ctx); err != nil {
+				return result, c.opError("import", nil, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, c.opError("import", nil, fmt.Errorf("read line %d: %w", lineNumber+1, err))
+	}
+	if err := importer.flush(ctx); err != nil {
+		return result, c.opError("import", nil, err)
+	}
+
+	return result, nil
+}
+
+// extJSONImporter accumulates write models for a batch of lines.
+type extJSONImporter struct {
+	collection *Collection
+	options    *ImportOptions
+	result     *ImportResult
+	models     []mongo.WriteModel
+	lines      []int
+}
+
+// add parses a line and adds the write model for it to the batch.
+func (ei *extJSONImporter) add(lineNumber int, line []byte) error {
+	var document bson.D
+	err := bson.UnmarshalExtJSON(line, false, &document)
+	if err != nil {
+		return ei.skip(lineNumber, fmt.Errorf("parse document: %w", err))
+	}
+	var model mongo.WriteModel = mongo.NewInsertOneModel().SetDocument(document)
+	if len(ei.options.UpsertKey) > 0 {
+		filter, err := upsertFilter(document, ei.options.UpsertKey)
+		if err != nil {
+			return ei.skip(lineNumber, err)
+		}
+		model = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(document).SetUpsert(true)
+	}
+	ei.models = append(ei.models, model)
+	ei.lines = append(ei.lines, lineNumber)
+	return nil
+}
+
+// flush writes the batch to the collection.
+// Failed documents are skipped if SkipErrors is set,
+// otherwise the batch is ordered and the first failed document is returned as an error.
+func (ei *extJSONImporter) flush(ctx context.Context) error {
+	if len(ei.models) == 0 {
+		return nil
+	}
+	defer func() {
+		ei.models = ei.models[:0]
+		ei.lines = ei.lines[:0]
+	}()
+
+	writeResult, err := ei.collection.BulkWrite(ctx, ei.models,
+		options.BulkWrite().SetOrdered(!ei.options.SkipErrors))
+	if writeResult != nil {
+		ei.result.Inserted += writeResult.InsertedCount
+		ei.result.Upserted += writeResult.UpsertedCount
+		ei.result.Modified += writeResult.ModifiedCount
+	}
+	if err == nil {
+		return nil
+	}
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return fmt.Errorf("write lines %d-%d: %w", ei.lines[0], ei.lines[len(ei.lines)-1], err)
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if skipErr := ei.skip(ei.lines[writeErr.Index], writeErr); skipErr != nil {
+			return skipErr
+		}
+	}
+	return nil
+}
+
+// skip records the failed line if SkipErrors is set, otherwise it returns the failure as an error.
+func (ei *extJSONImporter) skip(lineNumber int, err error) error {
+	importErr := &ImportError{Line: lineNumber, Err: err}
+	if !ei.options.SkipErrors {
+		return importErr
+	}
+	ei.result.Skipped = append(ei.result.Skipped, importErr)
+	return nil
+}
+
+// upsertFilter returns a filter matching the values of the key fields in the document.
+func upsertFilter(document bson.D, keys []string) (bson.D, error) {
+	filter := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		found := false
+		for _, elem := range document {
+			if elem.Key == key {
+				filter = append(filter, elem)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("missing upsert key field %s", key)
+		}
+	}
+	return filter, nil
+}
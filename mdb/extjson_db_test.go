@@ -0,0 +1,137 @@
+//go:build database
+
+package mdb
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type extJSONDbTestSuite struct {
+	AccessTestSuite
+	typed    *TypedCollection[SimpleItem]
+	imported *TypedCollection[SimpleItem]
+}
+
+func TestExtJSONDbSuite(t *testing.T) {
+	suite.Run(t, new(extJSONDbTestSuite))
+}
+
+func (suite *extJSONDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollection)
+	suite.imported = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollectionImport,
+		NewIndexDescription(true, "alpha"))
+}
+
+func (suite *extJSONDbTestSuite) SetupTest() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+}
+
+func (suite *extJSONDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+	suite.NoError(suite.imported.DeleteAll())
+}
+
+func (suite *extJSONDbTestSuite) export(canonical bool) *bytes.Buffer {
+	var buffer bytes.Buffer
+	count, err := suite.typed.ExportExtJSON(context.Background(), &buffer, nil, canonical,
+		options.Find().SetSort(bson.D{{Key: "bravo", Value: 1}}))
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), count)
+	return &buffer
+}
+
+func (suite *extJSONDbTestSuite) TestExport() {
+	lines := strings.Split(strings.TrimSpace(suite.export(true).String()), "\n")
+	suite.Require().Len(lines, 3)
+	suite.Contains(lines[0], `"alpha":"one"`)
+	suite.Contains(lines[0], `"$oid"`)
+	suite.Contains(lines[0], `"bravo":{"$numberInt":"1"}`)
+	var buffer bytes.Buffer
+	count, err := suite.typed.ExportExtJSON(context.Background(), &buffer, SimpleItem2.Filter(), false)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+	suite.Contains(buffer.String(), `"bravo":2`)
+}
+
+func (suite *extJSONDbTestSuite) TestRoundTrip() {
+	for _, canonical := range []bool{true, false} {
+		result, err := suite.imported.ImportExtJSON(context.Background(), suite.export(canonical),
+			&ImportOptions{BatchSize: 2})
+		suite.Require().NoError(err)
+		suite.Equal(int64(3), result.Inserted)
+		suite.Empty(result.Skipped)
+		for _, item := range []*SimpleItem{SimpleItem1, SimpleItem2, SimpleItem3} {
+			original, err := suite.typed.Find(item.Filter())
+			suite.Require().NoError(err)
+			copied, err := suite.imported.Find(item.Filter())
+			suite.Require().NoError(err)
+			suite.Equal(original, copied)
+		}
+		suite.Require().NoError(suite.imported.DeleteAll())
+	}
+}
+
+func (suite *extJSONDbTestSuite) TestUpsert() {
+	input := `{"alpha": "one", "bravo": 1}
+
+{"alpha": "two", "bravo": 2}
+`
+	result, err := suite.imported.ImportExtJSON(context.Background(), strings.NewReader(input),
+		&ImportOptions{UpsertKey: []string{"alpha"}})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), result.Upserted)
+	input = `{"alpha": "one", "bravo": 11}
+{"alpha": "three", "bravo": 3}
+`
+	result, err = suite.imported.ImportExtJSON(context.Background(), strings.NewReader(input),
+		&ImportOptions{UpsertKey: []string{"alpha"}})
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.Upserted)
+	suite.Equal(int64(1), result.Modified)
+	item, err := suite.imported.Find(SimpleItem1.Filter())
+	suite.Require().NoError(err)
+	suite.Equal(11, item.Bravo)
+	count, err := suite.imported.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), count)
+}
+
+func (suite *extJSONDbTestSuite) TestErrors() {
+	input := `{"alpha": "one", "bravo": 1}
+{"alpha": "one", "bravo": 2}
+{"alpha": 
+{"alpha": "three", "bravo": 3}
+`
+	result, err := suite.imported.ImportExtJSON(context.Background(), strings.NewReader(input))
+	var importErr *ImportError
+	suite.Require().ErrorAs(err, &importErr)
+	suite.Equal(3, importErr.Line)
+	suite.Zero(result.Inserted)
+	// Parse errors stop before the batch is written, duplicates are found when it is written:
+	suite.Require().NoError(suite.imported.DeleteAll())
+	result, err = suite.imported.ImportExtJSON(context.Background(), strings.NewReader(input),
+		&ImportOptions{BatchSize: 2})
+	suite.Require().ErrorAs(err, &importErr)
+	suite.Equal(2, importErr.Line)
+	suite.True(IsDuplicate(err))
+	suite.Equal(int64(1), result.Inserted)
+	suite.Require().NoError(suite.imported.DeleteAll())
+	result, err = suite.imported.ImportExtJSON(context.Background(), strings.NewReader(input),
+		&ImportOptions{SkipErrors: true})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), result.Inserted)
+	suite.Require().Len(result.Skipped, 2)
+	suite.Equal(3, result.Skipped[0].Line)
+	suite.Equal(2, result.Skipped[1].Line)
+	suite.True(IsDuplicate(result.Skipped[1]))
+}
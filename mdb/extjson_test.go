@@ -0,0 +1,78 @@
+package mdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type extJSONTestSuite struct {
+	suite.Suite
+}
+
+func TestExtJSONSuite(t *testing.T) {
+	suite.Run(t, new(extJSONTestSuite))
+}
+
+func (suite *extJSONTestSuite) TestUpsertFilter() {
+	document := bson.D{{Key: "_id", Value: 1}, {Key: "alpha", Value: "one"}, {Key: "bravo", Value: 1}}
+	filter, err := upsertFilter(document, []string{"bravo", "alpha"})
+	suite.Require().NoError(err)
+	suite.Equal(bson.D{{Key: "bravo", Value: 1}, {Key: "alpha", Value: "one"}}, filter)
+	_, err = upsertFilter(document, []string{"charlie"})
+	suite.ErrorContains(err, "charlie")
+}
+
+func (suite *extJSONTestSuite) TestImportError() {
+	err := &ImportError{Line: 17, Err: ErrInvalidGeometry}
+	suite.Equal("line 17: invalid geometry", err.Error())
+	suite.ErrorIs(err, ErrInvalidGeometry)
+}
+
+func (suite *extJSONTestSuite) TestImporterAdd() {
+	importer := &extJSONImporter{options: &ImportOptions{}, result: &ImportResult{}}
+	suite.Require().NoError(importer.add(1, []byte(`{"alpha": "one", "bravo": {"$numberInt": "1"}}`)))
+	suite.Require().Len(importer.models, 1)
+	insert, ok := importer.models[0].(*mongo.InsertOneModel)
+	suite.Require().True(ok)
+	suite.Equal(bson.D{{Key: "alpha", Value: "one"}, {Key: "bravo", Value: int32(1)}}, insert.Document)
+	suite.Equal([]int{1}, importer.lines)
+	err := importer.add(2, []byte(`{"alpha": `))
+	var importErr *ImportError
+	suite.Require().ErrorAs(err, &importErr)
+	suite.Equal(2, importErr.Line)
+}
+
+func (suite *extJSONTestSuite) TestImporterAddUpsert() {
+	importer := &extJSONImporter{
+		options: &ImportOptions{UpsertKey: []string{"alpha"}, SkipErrors: true},
+		result:  &ImportResult{},
+	}
+	suite.Require().NoError(importer.add(1, []byte(`{"alpha": "one"}`)))
+	suite.Require().Len(importer.models, 1)
+	replace, ok := importer.models[0].(*mongo.ReplaceOneModel)
+	suite.Require().True(ok)
+	suite.Equal(bson.D{{Key: "alpha", Value: "one"}}, replace.Filter)
+	suite.True(*replace.Upsert)
+	// Skipped lines:
+	suite.Require().NoError(importer.add(2, []byte(`{"bravo": 2}`)))
+	suite.Require().NoError(importer.add(3, []byte(`not json`)))
+	suite.Len(importer.models, 1)
+	suite.Require().Len(importer.result.Skipped, 2)
+	suite.Equal(2, importer.result.Skipped[0].Line)
+	suite.Equal(3, importer.result.Skipped[1].Line)
+}
+
+func (suite *extJSONTestSuite) TestMarshalFormats() {
+	raw, err := bson.Marshal(bson.D{{Key: "bravo", Value: int32(1)}})
+	suite.Require().NoError(err)
+	canonical, err := bson.MarshalExtJSON(bson.Raw(raw), true, false)
+	suite.Require().NoError(err)
+	suite.True(bytes.Contains(canonical, []byte(`"$numberInt"`)))
+	relaxed, err := bson.MarshalExtJSON(bson.Raw(raw), false, false)
+	suite.Require().NoError(err)
+	suite.Equal(`{"bravo":1}`, string(relaxed))
+}
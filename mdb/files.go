@@ -0,0 +1,277 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultBucketName is the GridFS bucket name used if none is specified.
+const DefaultBucketName = "fs"
+
+var (
+	// filesIndex is the index GridFS uses to find files by name.
+	filesIndex = NewIndexDescription(false, "filename", "uploadDate")
+
+	// chunksIndex is the index GridFS uses to read the chunks of a file in order.
+	chunksIndex = NewIndexDescription(true, "files_id", "n")
+)
+
+// Files provides GridFS storage for large files in a bucket.
+// The bucket uses two collections named <bucket name>.files and <bucket name>.chunks.
+// Operations use the deadline of the specified context or,
+// if it has none, the Files timeout from the Access configuration.
+type Files struct {
+	*Access
+	bucket *gridfs.Bucket
+	name   string
+}
+
+// FileInfo describes a file stored in GridFS.
+// The Metadata can be decoded into a custom type using bson.Unmarshal.
+type FileInfo = gridfs.File
+
+// Files returns a Files object for the named GridFS bucket, DefaultBucketName if the name is empty.
+func (a *Access) Files(bucketName string, opts ...*options.BucketOptions) (*Files, error) {
+	if bucketName == "" {
+		bucketName = DefaultBucketName
+	}
+	opts = append(opts, options.GridFSBucket().SetName(bucketName))
+	bucket, err := gridfs.NewBucket(a.database, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create bucket %s: %w", bucketName, err)
+	}
+	return &Files{Access: a, bucket: bucket, name: bucketName}, nil
+}
+
+// FilesFinisher returns a CollectionFinisher that creates the indexes for the named GridFS bucket.
+// Attach it to the definition of the collection holding documents that reference the files
+// so that the indexes exist before the first upload.
+func FilesFinisher(bucketName string) CollectionFinisher {
+	return func(access *Access, _ *Collection) error {
+		files, err := access.Files(bucketName)
+		if err != nil {
+			return err
+		}
+		return files.CreateIndexes()
+	}
+}
+
+// Bucket returns the underlying GridFS bucket.
+func (f *Files) Bucket() *gridfs.Bucket {
+	return f.bucket
+}
+
+// Name returns the name of the bucket.
+func (f *Files) Name() string {
+	return f.name
+}
+
+// ContextWithTimeout returns the base context with the timeout for file transfers.
+func (f *Files) ContextWithTimeout() (context.Context, context.CancelFunc) {
+	return f.Access.ContextWithTimeout(f.Access.config.Files)
+}
+
+// CreateIndexes creates the indexes GridFS uses for the files and chunks collections.
+// GridFS creates them on the first upload if necessary but creating them up front
+// avoids doing so during an application request.
+func (f *Files) CreateIndexes() error {
+	if err := f.Index(f.collection(f.bucket.GetFilesCollection().Name()), filesIndex); err != nil {
+		return fmt.Errorf("bucket %s: %w", f.name, err)
+	}
+	if err := f.Index(f.collection(f.bucket.GetChunksCollection().Name()), chunksIndex); err != nil {
+		return fmt.Errorf("bucket %s: %w", f.name, err)
+	}
+	return nil
+}
+
+// Upload reads the file contents from the reader and stores them with the specified name and metadata,
+// returning the ID of the new file.
+// The metadata may be nil or any object that can be marshaled to a BSON document.
+// The deadline applies to the entire upload.
+func (f *Files) Upload(ctx context.Context, name string, reader io.Reader, metadata interface{}) (interface{}, error) {
+	ctx, cancel := f.withTimeout(ctx)
+	defer cancel()
+	opts := options.GridFSUpload()
+	if metadata != nil {
+		opts.SetMetadata(metadata)
+	}
+	stream, err := f.bucket.OpenUploadStream(name, opts)
+	if err != nil {
+		return nil, f.opError("upload", bson.D{{Key: "filename", Value: name}}, err)
+	}
+	_ = stream.SetWriteDeadline(f.deadline(ctx))
+	if _, err = io.Copy(stream, &contextReader{ctx: ctx, reader: reader}); err != nil {
+		_ = stream.Abort()
+		return nil, f.opError("upload", bson.D{{Key: "filename", Value: name}}, err)
+	}
+	if err = stream.Close(); err != nil {
+		return nil, f.opError("upload", bson.D{{Key: "filename", Value: name}}, err)
+	}
+
+	return stream.FileID, nil
+}
+
+// Download writes the contents of the file with the specified ID to the writer,
+// returning the number of bytes written.
+// The deadline applies to the entire download.
+func (f *Files) Download(ctx context.Context, id interface{}, writer io.Writer) (int64, error) {
+	ctx, cancel := f.withTimeout(ctx)
+	defer cancel()
+	stream, err := f.OpenStream(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	written, err := io.Copy(&contextWriter{ctx: ctx, writer: writer}, stream)
+	if err != nil {
+		return written, f.opError("download", bson.D{{Key: "_id", Value: id}}, err)
+	}
+
+	return written, nil
+}
+
+// OpenStream opens the file with the specified ID for reading.
+// The caller must close the stream.
+// Use the GetFile method of the stream to get the FileInfo.
+// The deadline applies to looking up the file and reading the entire stream.
+// The driver then queries the files and chunks collections again to open the stream,
+// which is only bounded by a read deadline set on the Bucket.
+func (f *Files) OpenStream(ctx context.Context, id interface{}) (*gridfs.DownloadStream, error) {
+	filter := bson.D{{Key: "_id", Value: id}}
+	if err := f.findFile(ctx, filter); err != nil {
+		return nil, f.opError("download", filter, err)
+	}
+	// Bucket deadlines are shared so the deadline is set on the stream after the file is found.
+	stream, err := f.bucket.OpenDownloadStream(id)
+	if err != nil {
+		return nil, f.opError("download", filter, err)
+	}
+	_ = stream.SetReadDeadline(f.deadline(ctx))
+
+	return stream, nil
+}
+
+// Delete removes the file with the specified ID and all of its contents.
+func (f *Files) Delete(ctx context.Context, id interface{}) error {
+	ctx, cancel := f.withTimeout(ctx)
+	defer cancel()
+	if err := f.bucket.DeleteContext(ctx, id); err != nil {
+		return f.opError("delete", bson.D{{Key: "_id", Value: id}}, err)
+	}
+	return nil
+}
+
+// Find returns information about the files with metadata matching the filter.
+// The filter applies to the metadata document,
+// for example bson.D{{"owner", "fred"}} matches files with metadata.owner equal to "fred".
+// Use FindFiles to filter on other fields of the files collection.
+func (f *Files) Find(ctx context.Context, filter bson.D, opts ...*options.GridFSFindOptions) ([]*FileInfo, error) {
+	metadataFilter := make(bson.D, 0, len(filter))
+	for _, elem := range filter {
+		metadataFilter = append(metadataFilter, bson.E{Key: "metadata." + elem.Key, Value: elem.Value})
+	}
+	return f.FindFiles(ctx, metadataFilter, opts...)
+}
+
+// FindFiles returns information about the files matching a filter on the files collection,
+// which has fields such as filename, length, uploadDate, and metadata.
+func (f *Files) FindFiles(ctx context.Context, filter interface{}, opts ...*options.GridFSFindOptions) ([]*FileInfo, error) {
+	if filter == nil {
+		filter = NoFilter()
+	}
+	ctx, cancel := f.withTimeout(ctx)
+	defer cancel()
+	cursor, err := f.bucket.FindContext(ctx, filter, opts...)
+	if err != nil {
+		return nil, f.opError("find", filter, err)
+	}
+	files := make([]*FileInfo, 0)
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, f.opError("find", filter, err)
+	}
+
+	return files, nil
+}
+
+// findFile returns gridfs.ErrFileNotFound if no file matches the filter.
+func (f *Files) findFile(ctx context.Context, filter bson.D) error {
+	ctx, cancel := f.withTimeout(ctx)
+	defer cancel()
+	cursor, err := f.bucket.FindContext(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+	if !cursor.Next(ctx) {
+		if err = cursor.Err(); err != nil {
+			return err
+		}
+		return gridfs.ErrFileNotFound
+	}
+	return nil
+}
+
+// withTimeout returns the context with the Files timeout applied if it has no deadline.
+func (f *Files) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, f.Access.config.Files)
+}
+
+// deadline returns the deadline of the context or the Files timeout from now if it has none.
+func (f *Files) deadline(ctx context.Context) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(f.Access.config.Files)
+}
+
+// collection returns a Collection object for one of the bucket collections.
+func (f *Files) collection(name string) *Collection {
+	return &Collection{
+		Access:     f.Access,
+		Collection: f.Database().Collection(name),
+		ctx:        f.Context(),
+	}
+}
+
+// opError returns an OpError for the files collection of the bucket.
+func (f *Files) opError(op string, filter interface{}, err error) error {
+	return &OpError{Op: op, Collection: f.bucket.GetFilesCollection().Name(), Filter: filter, Err: err}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// contextReader stops reading when the context ends.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.reader.Read(p)
+}
+
+// contextWriter stops writing when the context ends.
+type contextWriter struct {
+	ctx    context.Context
+	writer io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cw.writer.Write(p)
+}
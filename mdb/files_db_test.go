@@ -0,0 +1,153 @@
+//go:build database
+
+package mdb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type filesDbTestSuite struct {
+	AccessTestSuite
+	files *Files
+}
+
+func TestFilesDbSuite(t *testing.T) {
+	suite.Run(t, new(filesDbTestSuite))
+}
+
+func (suite *filesDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	var err error
+	suite.files, err = suite.access.Files("test-files")
+	suite.Require().NoError(err)
+}
+
+func (suite *filesDbTestSuite) SetupTest() {
+	suite.Require().NoError(suite.files.CreateIndexes())
+}
+
+func (suite *filesDbTestSuite) TearDownTest() {
+	suite.NoError(suite.files.Bucket().DropContext(context.Background()))
+}
+
+type fileMetadata struct {
+	Owner string `bson:"owner"`
+	Kind  string `bson:"kind"`
+}
+
+func (suite *filesDbTestSuite) TestUploadDownload() {
+	ctx, cancel := suite.files.ContextWithTimeout()
+	defer cancel()
+	contents := strings.Repeat("0123456789", 100000)
+	id, err := suite.files.Upload(ctx, "digits.txt", strings.NewReader(contents),
+		&fileMetadata{Owner: "fred", Kind: "text"})
+	suite.Require().NoError(err)
+	suite.NotNil(id)
+	var buffer bytes.Buffer
+	written, err := suite.files.Download(ctx, id, &buffer)
+	suite.Require().NoError(err)
+	suite.Equal(int64(len(contents)), written)
+	suite.Equal(contents, buffer.String())
+}
+
+func (suite *filesDbTestSuite) TestOpenStream() {
+	ctx := context.Background()
+	id, err := suite.files.Upload(ctx, "hello.txt", strings.NewReader("hello"), nil)
+	suite.Require().NoError(err)
+	stream, err := suite.files.OpenStream(ctx, id)
+	suite.Require().NoError(err)
+	defer func() { suite.NoError(stream.Close()) }()
+	suite.Equal("hello.txt", stream.GetFile().Name)
+	suite.Equal(int64(5), stream.GetFile().Length)
+	contents, err := io.ReadAll(stream)
+	suite.Require().NoError(err)
+	suite.Equal("hello", string(contents))
+}
+
+func (suite *filesDbTestSuite) TestOpenStreamExpired() {
+	id, err := suite.files.Upload(context.Background(), "late.txt", strings.NewReader("late"), nil)
+	suite.Require().NoError(err)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = suite.files.OpenStream(ctx, id)
+	suite.True(IsTimeout(err))
+	_, err = suite.files.OpenStream(context.Background(), "missing")
+	suite.True(IsNotFound(err))
+}
+
+func (suite *filesDbTestSuite) TestFind() {
+	ctx := context.Background()
+	for _, file := range []struct{ name, owner string }{
+		{"one.txt", "fred"}, {"two.txt", "barney"}, {"three.txt", "fred"},
+	} {
+		_, err := suite.files.Upload(ctx, file.name, strings.NewReader(file.name),
+			&fileMetadata{Owner: file.owner, Kind: "text"})
+		suite.Require().NoError(err)
+	}
+	found, err := suite.files.Find(ctx, bson.D{{Key: "owner", Value: "fred"}},
+		options.GridFSFind().SetSort(bson.D{{Key: "filename", Value: 1}}))
+	suite.Require().NoError(err)
+	suite.Require().Len(found, 2)
+	suite.Equal("one.txt", found[0].Name)
+	suite.Equal("three.txt", found[1].Name)
+	var metadata fileMetadata
+	suite.Require().NoError(bson.Unmarshal(found[0].Metadata, &metadata))
+	suite.Equal("fred", metadata.Owner)
+	found, err = suite.files.Find(ctx, bson.D{{Key: "owner", Value: "wilma"}})
+	suite.Require().NoError(err)
+	suite.Empty(found)
+	found, err = suite.files.FindFiles(ctx, bson.D{{Key: "filename", Value: "two.txt"}})
+	suite.Require().NoError(err)
+	suite.Require().Len(found, 1)
+	found, err = suite.files.FindFiles(ctx, nil)
+	suite.Require().NoError(err)
+	suite.Len(found, 3)
+}
+
+func (suite *filesDbTestSuite) TestDelete() {
+	ctx := context.Background()
+	id, err := suite.files.Upload(ctx, "gone.txt", strings.NewReader("gone"), nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.files.Delete(ctx, id))
+	_, err = suite.files.Download(ctx, id, io.Discard)
+	suite.True(IsNotFound(err))
+	suite.True(IsNotFound(suite.files.Delete(ctx, id)))
+}
+
+func (suite *filesDbTestSuite) TestCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := suite.files.Upload(ctx, "never.txt", strings.NewReader("never"), nil)
+	suite.ErrorIs(err, context.Canceled)
+	found, err := suite.files.FindFiles(context.Background(), nil)
+	suite.Require().NoError(err)
+	suite.Empty(found)
+}
+
+func (suite *filesDbTestSuite) TestIndexes() {
+	NewIndexTester().TestIndexes(suite.T(),
+		suite.files.collection(suite.files.Bucket().GetFilesCollection().Name()), filesIndex)
+	NewIndexTester().TestIndexes(suite.T(),
+		suite.files.collection(suite.files.Bucket().GetChunksCollection().Name()), chunksIndex)
+}
+
+func (suite *filesDbTestSuite) TestFinisher() {
+	collection := suite.ConnectCollection(&CollectionDefinition{
+		Name:      "test-collection-files-owner",
+		Finishers: []CollectionFinisher{FilesFinisher("test-files-finished")},
+	})
+	defer func() { suite.NoError(collection.Drop()) }()
+	files, err := suite.access.Files("test-files-finished")
+	suite.Require().NoError(err)
+	NewIndexTester().TestIndexes(suite.T(),
+		files.collection(files.Bucket().GetChunksCollection().Name()), chunksIndex)
+}
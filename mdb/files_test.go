@@ -0,0 +1,65 @@
+package mdb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type filesTestSuite struct {
+	suite.Suite
+}
+
+func TestFilesSuite(t *testing.T) {
+	suite.Run(t, new(filesTestSuite))
+}
+
+func (suite *filesTestSuite) TestContextReader() {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &contextReader{ctx: ctx, reader: strings.NewReader("contents")}
+	buffer := make([]byte, 4)
+	n, err := reader.Read(buffer)
+	suite.Require().NoError(err)
+	suite.Equal("cont", string(buffer[:n]))
+	cancel()
+	_, err = reader.Read(buffer)
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *filesTestSuite) TestContextWriter() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var buffer bytes.Buffer
+	writer := &contextWriter{ctx: ctx, writer: &buffer}
+	_, err := io.WriteString(writer, "contents")
+	suite.Require().NoError(err)
+	suite.Equal("contents", buffer.String())
+	cancel()
+	_, err = io.WriteString(writer, "more")
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal("contents", buffer.String())
+}
+
+func (suite *filesTestSuite) TestTimeout() {
+	files := &Files{Access: &Access{config: Config{Timeout: Timeout{Files: time.Hour}}}}
+	ctx, cancel := files.withTimeout(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	suite.Require().True(ok)
+	suite.WithinDuration(time.Now().Add(time.Hour), deadline, time.Minute)
+	suite.WithinDuration(time.Now().Add(time.Hour), files.deadline(context.Background()), time.Minute)
+	// Existing deadline is kept:
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+	kept, keptCancel := files.withTimeout(ctx)
+	defer keptCancel()
+	deadline, ok = kept.Deadline()
+	suite.Require().True(ok)
+	suite.Equal(expected, deadline)
+	suite.Equal(expected, files.deadline(ctx))
+}
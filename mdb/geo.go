@@ -0,0 +1,153 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultDistanceField is the field into which Near projects the distance if no field is specified.
+const DefaultDistanceField = "_distance"
+
+const geoKeyType = "2dsphere"
+
+// NewGeoIndexDescription creates a 2dsphere index description for a GeoJSON field,
+// required by Near and used by Within and Intersects.
+// Additional fields may be specified with ascending keys to support filters used with geospatial queries.
+func NewGeoIndexDescription(field string, otherFields ...string) *IndexDescription {
+	keySpec := bson.D{{Key: field, Value: geoKeyType}}
+	for _, other := range otherFields {
+		keySpec = append(keySpec, bson.E{Key: other, Value: 1})
+	}
+	return NewIndexDescriptionSpec(keySpec)
+}
+
+// NearOptions configures Near.
+type NearOptions struct {
+	// MinDistance in meters, zero for no minimum.
+	MinDistance float64
+
+	// MaxDistance in meters, zero for no maximum.
+	MaxDistance float64
+
+	// Limit on the number of results, zero for no limit.
+	Limit int64
+
+	// DistanceField is the field into which the distance is projected, default DefaultDistanceField.
+	// Use the name of a float64 field in the collection type to have the distance decoded into items.
+	DistanceField string
+}
+
+// GeoResult is an item found by Near with its distance in meters from the point.
+type GeoResult[T any] struct {
+	Item     *T
+	Distance float64
+}
+
+// Near finds items matching the filter with a GeoJSON field near the point, ordered by increasing distance.
+// The field must have a 2dsphere index, see NewGeoIndexDescription.
+// If the collection has more than one 2dsphere index the field selects which one is used.
+func (c *TypedCollection[T]) Near(
+	ctx context.Context, field string, point Point, filter bson.D, opts ...*NearOptions) ([]GeoResult[T], error) {
+	if err := point.Validate(); err != nil {
+		return nil, c.opError("near", filter, err)
+	}
+	opt := &NearOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	distanceField := opt.DistanceField
+	if distanceField == "" {
+		distanceField = DefaultDistanceField
+	}
+
+	geoNear := bson.D{
+		{Key: "near", Value: point},
+		{Key: "distanceField", Value: distanceField},
+		{Key: "key", Value: field},
+		{Key: "spherical", Value: true},
+	}
+	if opt.MinDistance > 0 {
+		geoNear = append(geoNear, bson.E{Key: "minDistance", Value: opt.MinDistance})
+	}
+	if opt.MaxDistance > 0 {
+		geoNear = append(geoNear, bson.E{Key: "maxDistance", Value: opt.MaxDistance})
+	}
+	if len(filter) > 0 || c.SoftDeleted() {
+		geoNear = append(geoNear, bson.E{Key: "query", Value: c.activeFilter(filter)})
+	}
+	pipeline := NewPipeline().Stage("$geoNear", geoNear)
+	if opt.Limit > 0 {
+		pipeline = pipeline.Limit(opt.Limit)
+	}
+
+	cursor, err := c.Collection.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, c.opError("near", filter, err)
+	}
+	results := make([]GeoResult[T], 0)
+	err = iterateCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		item, err := decodeItem[T](ctx, cursor.Decode)
+		if err != nil {
+			return err
+		}
+		result := GeoResult[T]{Item: item}
+		result.Distance, _ = cursor.Current.Lookup(distanceField).DoubleOK()
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, c.opError("near", filter, err)
+	}
+
+	return results, nil
+}
+
+// Within finds items matching the filter with a GeoJSON field entirely within the Polygon or MultiPolygon.
+// Options may be used to specify sort, skip, limit, or projection.
+func (c *TypedCollection[T]) Within(
+	ctx context.Context, field string, area Geometry, filter bson.D, opts ...*options.FindOptions) ([]*T, error) {
+	switch area.(type) {
+	case Polygon, *Polygon, MultiPolygon, *MultiPolygon:
+	default:
+		return nil, c.opError("within", filter,
+			fmt.Errorf("%w: %s is not a Polygon or MultiPolygon", ErrInvalidGeometry, area.GeometryType()))
+	}
+	return c.findGeometry(ctx, "within", "$geoWithin", field, area, filter, opts...)
+}
+
+// Intersects finds items matching the filter with a GeoJSON field that intersects the geometry.
+// Options may be used to specify sort, skip, limit, or projection.
+func (c *TypedCollection[T]) Intersects(
+	ctx context.Context, field string, geometry Geometry, filter bson.D, opts ...*options.FindOptions) ([]*T, error) {
+	return c.findGeometry(ctx, "intersects", "$geoIntersects", field, geometry, filter, opts...)
+}
+
+// findGeometry finds items with the geospatial operator applied to the field and geometry.
+func (c *TypedCollection[T]) findGeometry(
+	ctx context.Context, op, operator, field string, geometry Geometry, filter bson.D, opts ...*options.FindOptions) ([]*T, error) {
+	if err := geometry.Validate(); err != nil {
+		return nil, c.opError(op, filter, fmt.Errorf("%s: %w", geometry.GeometryType(), err))
+	}
+	condition := bson.E{Key: field, Value: bson.D{
+		{Key: operator, Value: bson.D{{Key: "$geometry", Value: geometry}}},
+	}}
+	cursor, err := c.Collection.Collection.Find(ctx, c.activeFilter(addCondition(filter, condition)), opts...)
+	if err != nil {
+		return nil, c.opError(op, filter, err)
+	}
+	items := make([]*T, 0)
+	if err = cursor.All(ctx, &items); err != nil {
+		return nil, c.opError(op, filter, err)
+	}
+	for _, item := range items {
+		if err = afterFind(ctx, item); err != nil {
+			return nil, c.opError(op, filter, err)
+		}
+	}
+
+	return items, nil
+}
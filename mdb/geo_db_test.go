@@ -0,0 +1,122 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type geoDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[place]
+}
+
+type place struct {
+	Name     string  `bson:"name"`
+	Kind     string  `bson:"kind"`
+	Location Point   `bson:"location"`
+	Distance float64 `bson:"distance,omitempty"`
+}
+
+func TestGeoDbSuite(t *testing.T) {
+	suite.Run(t, new(geoDbTestSuite))
+}
+
+func (suite *geoDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[place](&suite.AccessTestSuite, testCollectionGeo,
+		NewGeoIndexDescription("location"))
+}
+
+func (suite *geoDbTestSuite) SetupTest() {
+	for _, p := range []*place{
+		{Name: "origin", Kind: "corner", Location: *NewPoint(0, 0)},
+		{Name: "inside", Kind: "center", Location: *NewPoint(0.5, 0.5)},
+		{Name: "edge", Kind: "corner", Location: *NewPoint(1, 0.001)},
+		{Name: "outside", Kind: "center", Location: *NewPoint(2, 2)},
+	} {
+		suite.Require().NoError(suite.typed.Create(p))
+	}
+}
+
+func (suite *geoDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *geoDbTestSuite) TestIndex() {
+	NewIndexTester().TestIndexes(suite.T(), &suite.typed.Collection, NewGeoIndexDescription("location"))
+}
+
+func (suite *geoDbTestSuite) TestNear() {
+	results, err := suite.typed.Near(context.Background(), "location", *NewPoint(0, 0), nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 4)
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.Item.Name
+	}
+	suite.Equal([]string{"origin", "inside", "edge", "outside"}, names)
+	suite.Zero(results[0].Distance)
+	suite.InDelta(78600.0, results[1].Distance, 500.0)
+	suite.Less(results[1].Distance, results[2].Distance)
+}
+
+func (suite *geoDbTestSuite) TestNearOptions() {
+	results, err := suite.typed.Near(context.Background(), "location", *NewPoint(0, 0),
+		bson.D{{Key: "kind", Value: "center"}},
+		&NearOptions{MaxDistance: 100000, DistanceField: "distance"})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.Equal("inside", results[0].Item.Name)
+	suite.Equal(results[0].Distance, results[0].Item.Distance)
+	results, err = suite.typed.Near(context.Background(), "location", *NewPoint(0, 0), nil,
+		&NearOptions{MinDistance: 1, Limit: 2})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 2)
+	suite.Equal("inside", results[0].Item.Name)
+	suite.Equal("edge", results[1].Item.Name)
+}
+
+func (suite *geoDbTestSuite) TestNearInvalid() {
+	_, err := suite.typed.Near(context.Background(), "location", *NewPoint(200, 0), nil)
+	suite.ErrorIs(err, ErrInvalidGeometry)
+}
+
+func (suite *geoDbTestSuite) TestWithin() {
+	square := NewPolygon(Position{-0.1, -0.1}, Position{1.1, -0.1}, Position{1.1, 1.1}, Position{-0.1, 1.1})
+	items, err := suite.typed.Within(context.Background(), "location", square, nil,
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	suite.Require().NoError(err)
+	suite.Require().Len(items, 3)
+	suite.Equal("edge", items[0].Name)
+	items, err = suite.typed.Within(context.Background(), "location", square, bson.D{{Key: "kind", Value: "center"}})
+	suite.Require().NoError(err)
+	suite.Require().Len(items, 1)
+	suite.Equal("inside", items[0].Name)
+	_, err = suite.typed.Within(context.Background(), "location", NewPoint(0, 0), nil)
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	_, err = suite.typed.Within(context.Background(), "location",
+		&Polygon{Coordinates: [][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}}, nil)
+	suite.ErrorIs(err, ErrInvalidGeometry)
+}
+
+func (suite *geoDbTestSuite) TestIntersects() {
+	// Meridians are great circles so the line passes exactly through the origin.
+	line := &LineString{Coordinates: []Position{{0, -1}, {0, 1}}}
+	items, err := suite.typed.Intersects(context.Background(), "location", line, nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(items, 1)
+	suite.Equal("origin", items[0].Name)
+	square := NewPolygon(Position{0.4, 0.4}, Position{2.1, 0.4}, Position{2.1, 2.1}, Position{0.4, 2.1})
+	items, err = suite.typed.Intersects(context.Background(), "location", square, nil,
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	suite.Require().NoError(err)
+	suite.Require().Len(items, 2)
+	suite.Equal("inside", items[0].Name)
+	suite.Equal("outside", items[1].Name)
+}
@@ -0,0 +1,382 @@
+package mdb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidGeometry is returned when a GeoJSON geometry would be rejected by the server.
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+// Geometry is a GeoJSON geometry object.
+// Geometries are validated when marshaled so that invalid values are rejected before reaching the server.
+// Use pointer fields for optional geometries so that missing values are omitted instead of failing validation.
+type Geometry interface {
+	// GeometryType returns the GeoJSON type name, for example "Point".
+	GeometryType() string
+
+	// Validate returns an error wrapping ErrInvalidGeometry if the geometry is not valid.
+	Validate() error
+}
+
+// Position is a GeoJSON position as longitude and latitude in that order.
+type Position [2]float64
+
+// Longitude returns the longitude of the position.
+func (p Position) Longitude() float64 {
+	return p[0]
+}
+
+// Latitude returns the latitude of the position.
+func (p Position) Latitude() float64 {
+	return p[1]
+}
+
+// Validate returns an error if the longitude or latitude is out of range.
+func (p Position) Validate() error {
+	if p[0] < -180 || p[0] > 180 {
+		return fmt.Errorf("%w: longitude %v out of range", ErrInvalidGeometry, p[0])
+	}
+	if p[1] < -90 || p[1] > 90 {
+		return fmt.Errorf("%w: latitude %v out of range", ErrInvalidGeometry, p[1])
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	_ Geometry = Point{}
+	_ Geometry = LineString{}
+	_ Geometry = Polygon{}
+	_ Geometry = MultiPoint{}
+	_ Geometry = MultiLineString{}
+	_ Geometry = MultiPolygon{}
+)
+
+// Point is a GeoJSON Point.
+type Point struct {
+	Coordinates Position
+}
+
+// NewPoint returns a Point at the specified longitude and latitude.
+func NewPoint(longitude, latitude float64) *Point {
+	return &Point{Coordinates: Position{longitude, latitude}}
+}
+
+// GeometryType returns "Point".
+func (p Point) GeometryType() string {
+	return "Point"
+}
+
+// Validate returns an error if the position is out of range.
+func (p Point) Validate() error {
+	return p.Coordinates.Validate()
+}
+
+// MarshalBSON marshals the point as a GeoJSON document after validation.
+func (p Point) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(p, p.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON Point document.
+func (p *Point) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, p.GeometryType(), &p.Coordinates)
+}
+
+// LineString is a GeoJSON LineString of two or more positions.
+type LineString struct {
+	Coordinates []Position
+}
+
+// GeometryType returns "LineString".
+func (ls LineString) GeometryType() string {
+	return "LineString"
+}
+
+// Validate returns an error if there are fewer than two positions or a position is out of range.
+func (ls LineString) Validate() error {
+	return validateLine(ls.Coordinates)
+}
+
+// MarshalBSON marshals the line string as a GeoJSON document after validation.
+func (ls LineString) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(ls, ls.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON LineString document.
+func (ls *LineString) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, ls.GeometryType(), &ls.Coordinates)
+}
+
+// Polygon is a GeoJSON Polygon.
+// The first ring is the exterior, any other rings are holes within it.
+// Each ring is closed, so the first and last positions are the same,
+// and has at least four positions.
+type Polygon struct {
+	Coordinates [][]Position
+}
+
+// NewPolygon returns a Polygon with an exterior ring through the specified positions,
+// closing the ring if the last position is not the same as the first.
+func NewPolygon(exterior ...Position) *Polygon {
+	if len(exterior) > 0 && exterior[0] != exterior[len(exterior)-1] {
+		exterior = append(exterior[:len(exterior):len(exterior)], exterior[0])
+	}
+	return &Polygon{Coordinates: [][]Position{exterior}}
+}
+
+// GeometryType returns "Polygon".
+func (p Polygon) GeometryType() string {
+	return "Polygon"
+}
+
+// Validate returns an error if there are no rings or a ring is invalid.
+func (p Polygon) Validate() error {
+	return validatePolygon(p.Coordinates)
+}
+
+// MarshalBSON marshals the polygon as a GeoJSON document after validation.
+func (p Polygon) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(p, p.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON Polygon document.
+func (p *Polygon) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, p.GeometryType(), &p.Coordinates)
+}
+
+// MultiPoint is a GeoJSON MultiPoint.
+type MultiPoint struct {
+	Coordinates []Position
+}
+
+// GeometryType returns "MultiPoint".
+func (mp MultiPoint) GeometryType() string {
+	return "MultiPoint"
+}
+
+// Validate returns an error if there are no positions or a position is out of range.
+func (mp MultiPoint) Validate() error {
+	if len(mp.Coordinates) < 1 {
+		return fmt.Errorf("%w: no points", ErrInvalidGeometry)
+	}
+	for _, position := range mp.Coordinates {
+		if err := position.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalBSON marshals the points as a GeoJSON document after validation.
+func (mp MultiPoint) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(mp, mp.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON MultiPoint document.
+func (mp *MultiPoint) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, mp.GeometryType(), &mp.Coordinates)
+}
+
+// MultiLineString is a GeoJSON MultiLineString.
+type MultiLineString struct {
+	Coordinates [][]Position
+}
+
+// GeometryType returns "MultiLineString".
+func (mls MultiLineString) GeometryType() string {
+	return "MultiLineString"
+}
+
+// Validate returns an error if there are no lines or a line is invalid.
+func (mls MultiLineString) Validate() error {
+	if len(mls.Coordinates) < 1 {
+		return fmt.Errorf("%w: no lines", ErrInvalidGeometry)
+	}
+	for i, line := range mls.Coordinates {
+		if err := validateLine(line); err != nil {
+			return fmt.Errorf("line %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// MarshalBSON marshals the lines as a GeoJSON document after validation.
+func (mls MultiLineString) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(mls, mls.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON MultiLineString document.
+func (mls *MultiLineString) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, mls.GeometryType(), &mls.Coordinates)
+}
+
+// MultiPolygon is a GeoJSON MultiPolygon.
+type MultiPolygon struct {
+	Coordinates [][][]Position
+}
+
+// GeometryType returns "MultiPolygon".
+func (mp MultiPolygon) GeometryType() string {
+	return "MultiPolygon"
+}
+
+// Validate returns an error if there are no polygons or a polygon is invalid.
+func (mp MultiPolygon) Validate() error {
+	if len(mp.Coordinates) < 1 {
+		return fmt.Errorf("%w: no polygons", ErrInvalidGeometry)
+	}
+	for i, polygon := range mp.Coordinates {
+		if err := validatePolygon(polygon); err != nil {
+			return fmt.Errorf("polygon %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// MarshalBSON marshals the polygons as a GeoJSON document after validation.
+func (mp MultiPolygon) MarshalBSON() ([]byte, error) {
+	return marshalGeometry(mp, mp.Coordinates)
+}
+
+// UnmarshalBSON unmarshals a GeoJSON MultiPolygon document.
+func (mp *MultiPolygon) UnmarshalBSON(data []byte) error {
+	return unmarshalGeometry(data, mp.GeometryType(), &mp.Coordinates)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// geometryDocument is the BSON form of a GeoJSON geometry.
+type geometryDocument struct {
+	Type        string      `bson:"type"`
+	Coordinates interface{} `bson:"coordinates"`
+}
+
+// marshalGeometry validates the geometry and marshals it with the coordinates.
+func marshalGeometry(geometry Geometry, coordinates interface{}) ([]byte, error) {
+	if err := geometry.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", geometry.GeometryType(), err)
+	}
+	return bson.Marshal(geometryDocument{Type: geometry.GeometryType(), Coordinates: coordinates})
+}
+
+// unmarshalGeometry checks the geometry type and unmarshals the coordinates.
+func unmarshalGeometry(data []byte, geometryType string, coordinates interface{}) error {
+	raw := bson.Raw(data)
+	if actual, ok := raw.Lookup("type").StringValueOK(); !ok || actual != geometryType {
+		return fmt.Errorf("%w: type %q not %s", ErrInvalidGeometry, actual, geometryType)
+	}
+	value, err := raw.LookupErr("coordinates")
+	if err != nil {
+		return fmt.Errorf("%w: %s coordinates: %v", ErrInvalidGeometry, geometryType, err)
+	}
+	if err = value.Unmarshal(coordinates); err != nil {
+		return fmt.Errorf("%s coordinates: %w", geometryType, err)
+	}
+	return nil
+}
+
+// validateLine checks that a line has at least two positions within range.
+func validateLine(line []Position) error {
+	if len(line) < 2 {
+		return fmt.Errorf("%w: line has %d positions, at least 2 required", ErrInvalidGeometry, len(line))
+	}
+	for _, position := range line {
+		if err := position.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePolygon checks that a polygon has at least one ring and that every ring is valid.
+func validatePolygon(rings [][]Position) error {
+	if len(rings) < 1 {
+		return fmt.Errorf("%w: polygon has no rings", ErrInvalidGeometry)
+	}
+	for i, ring := range rings {
+		if err := validateRing(ring); err != nil {
+			return fmt.Errorf("ring %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateRing checks that a ring is closed, has at least four positions within range,
+// has no repeated consecutive positions, encloses an area, and does not intersect itself.
+func validateRing(ring []Position) error {
+	if len(ring) < 4 {
+		return fmt.Errorf("%w: ring has %d positions, at least 4 required", ErrInvalidGeometry, len(ring))
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return fmt.Errorf("%w: ring is not closed", ErrInvalidGeometry)
+	}
+	for i, position := range ring {
+		if err := position.Validate(); err != nil {
+			return err
+		}
+		if i > 0 && position == ring[i-1] {
+			return fmt.Errorf("%w: ring repeats position %d", ErrInvalidGeometry, i)
+		}
+	}
+	// Shoelace formula, zero if all positions are on a line.
+	var area float64
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	if area == 0 {
+		return fmt.Errorf("%w: ring has no area", ErrInvalidGeometry)
+	}
+	// Edge i runs from position i to i+1, the last edge ends at the first position.
+	edges := len(ring) - 1
+	for i := 0; i < edges; i++ {
+		// Adjacent edges share a position and may only overlap by doubling back.
+		next := (i + 1) % edges
+		if onSegment(ring[i], ring[i+1], ring[next+1]) || onSegment(ring[next], ring[next+1], ring[i]) {
+			return fmt.Errorf("%w: ring edges %d and %d overlap", ErrInvalidGeometry, i, next)
+		}
+		for j := i + 2; j < edges; j++ {
+			if i == 0 && j == edges-1 {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return fmt.Errorf("%w: ring edges %d and %d intersect", ErrInvalidGeometry, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// segmentsIntersect returns true if segment a-b and segment c-d have any point in common.
+func segmentsIntersect(a, b, c, d Position) bool {
+	abc, abd := orientation(a, b, c), orientation(a, b, d)
+	cda, cdb := orientation(c, d, a), orientation(c, d, b)
+	if abc != abd && cda != cdb && abc != 0 && abd != 0 && cda != 0 && cdb != 0 {
+		return true
+	}
+	return onSegment(a, b, c) || onSegment(a, b, d) || onSegment(c, d, a) || onSegment(c, d, b)
+}
+
+// onSegment returns true if position p lies on segment a-b.
+func onSegment(a, b, p Position) bool {
+	return orientation(a, b, p) == 0 &&
+		p[0] >= math.Min(a[0], b[0]) && p[0] <= math.Max(a[0], b[0]) &&
+		p[1] >= math.Min(a[1], b[1]) && p[1] <= math.Max(a[1], b[1])
+}
+
+// orientation returns 1 if a-b-c turns counterclockwise, -1 if clockwise, and 0 if they are on a line.
+func orientation(a, b, c Position) int {
+	cross := (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,143 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type geoJSONTestSuite struct {
+	suite.Suite
+}
+
+func TestGeoJSONSuite(t *testing.T) {
+	suite.Run(t, new(geoJSONTestSuite))
+}
+
+var (
+	testSquare = []Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	testHole   = []Position{{0.25, 0.25}, {0.75, 0.25}, {0.75, 0.75}, {0.25, 0.25}}
+)
+
+type geoHolder struct {
+	Location Point    `bson:"location"`
+	Area     *Polygon `bson:"area,omitempty"`
+}
+
+func (suite *geoJSONTestSuite) TestMarshalPoint() {
+	raw, err := bson.Marshal(&geoHolder{Location: *NewPoint(-73.97, 40.77)})
+	suite.Require().NoError(err)
+	var document bson.D
+	suite.Require().NoError(bson.Unmarshal(raw, &document))
+	suite.Equal(bson.D{{Key: "location", Value: bson.D{
+		{Key: "type", Value: "Point"},
+		{Key: "coordinates", Value: bson.A{-73.97, 40.77}},
+	}}}, document)
+	var holder geoHolder
+	suite.Require().NoError(bson.Unmarshal(raw, &holder))
+	suite.Equal(-73.97, holder.Location.Coordinates.Longitude())
+	suite.Equal(40.77, holder.Location.Coordinates.Latitude())
+	suite.Nil(holder.Area)
+}
+
+func (suite *geoJSONTestSuite) TestRoundTrip() {
+	for _, geometry := range []Geometry{
+		NewPoint(1, 2),
+		&LineString{Coordinates: []Position{{0, 0}, {1, 1}}},
+		&Polygon{Coordinates: [][]Position{testSquare, testHole}},
+		&MultiPoint{Coordinates: []Position{{0, 0}, {1, 1}}},
+		&MultiLineString{Coordinates: [][]Position{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}}},
+		&MultiPolygon{Coordinates: [][][]Position{{testSquare}, {testHole}}},
+	} {
+		raw, err := bson.Marshal(geometry)
+		suite.Require().NoError(err, geometry.GeometryType())
+		suite.Equal(geometry.GeometryType(), bson.Raw(raw).Lookup("type").StringValue())
+		switch expected := geometry.(type) {
+		case *Point:
+			var actual Point
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		case *LineString:
+			var actual LineString
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		case *Polygon:
+			var actual Polygon
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		case *MultiPoint:
+			var actual MultiPoint
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		case *MultiLineString:
+			var actual MultiLineString
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		case *MultiPolygon:
+			var actual MultiPolygon
+			suite.Require().NoError(bson.Unmarshal(raw, &actual))
+			suite.Equal(*expected, actual)
+		}
+	}
+}
+
+func (suite *geoJSONTestSuite) TestUnmarshalWrongType() {
+	raw, err := bson.Marshal(NewPoint(1, 2))
+	suite.Require().NoError(err)
+	var polygon Polygon
+	suite.ErrorIs(bson.Unmarshal(raw, &polygon), ErrInvalidGeometry)
+}
+
+func (suite *geoJSONTestSuite) TestNewPolygon() {
+	polygon := NewPolygon(Position{0, 0}, Position{1, 0}, Position{1, 1}, Position{0, 1})
+	suite.Equal([][]Position{testSquare}, polygon.Coordinates)
+	suite.NoError(polygon.Validate())
+	closed := NewPolygon(testSquare...)
+	suite.Equal([][]Position{testSquare}, closed.Coordinates)
+}
+
+func (suite *geoJSONTestSuite) TestValidate() {
+	suite.NoError(NewPoint(180, -90).Validate())
+	suite.ErrorIs(NewPoint(181, 0).Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(NewPoint(0, 91).Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(LineString{Coordinates: []Position{{0, 0}}}.Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(Polygon{}.Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(MultiPoint{}.Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(MultiLineString{Coordinates: [][]Position{{{0, 0}}}}.Validate(), ErrInvalidGeometry)
+	suite.ErrorIs(MultiPolygon{}.Validate(), ErrInvalidGeometry)
+}
+
+func (suite *geoJSONTestSuite) TestValidateRing() {
+	suite.NoError(validateRing(testSquare))
+	err := validateRing(testSquare[:4])
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "not closed")
+	err = validateRing([]Position{{0, 0}, {1, 1}, {0, 0}})
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "at least 4")
+	err = validateRing([]Position{{0, 0}, {1, 1}, {2, 2}, {0, 0}})
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "no area")
+	err = NewPolygon(Position{0, 0}, Position{0, 0}, Position{1, 0}, Position{1, 1}).Validate()
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "repeats position 1")
+	err = NewPolygon(Position{0, 0}, Position{2, 2}, Position{2, 0}, Position{0, 1}).Validate()
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "edges 0 and 2 intersect")
+	err = NewPolygon(Position{0, 0}, Position{2, 0}, Position{1, 0}, Position{1, 1}).Validate()
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "overlap")
+	suite.NoError(NewPolygon(Position{0, 0}, Position{2, 0}, Position{1, 1}, Position{2, 2}, Position{0, 2}).Validate())
+	err = Polygon{Coordinates: [][]Position{testSquare, testHole[:3]}}.Validate()
+	suite.ErrorIs(err, ErrInvalidGeometry)
+	suite.Contains(err.Error(), "ring 1")
+}
+
+func (suite *geoJSONTestSuite) TestMarshalInvalid() {
+	_, err := bson.Marshal(&geoHolder{
+		Location: *NewPoint(0, 0),
+		Area:     &Polygon{Coordinates: [][]Position{testSquare[:4]}},
+	})
+	suite.ErrorIs(err, ErrInvalidGeometry)
+}
@@ -0,0 +1,109 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Lifecycle hooks are optional interfaces implemented by the item type of a TypedCollection,
+// usually with pointer receivers.
+// Returning an error from a Before hook aborts the operation and the error is returned wrapped.
+// An error from an After hook is returned wrapped but the operation has already happened.
+//
+// Operator updates such as Update and FindOneAndUpdate don't call BeforeUpdate
+// as there is no item, only a set of changes.
+// Untyped operations on the embedded Collection and Aggregate don't call hooks.
+
+// BeforeCreator is called before an item is inserted by Create, FindOrCreate, CreateMany, or BulkWriter.Insert.
+// It is called before timestamps are set.
+type BeforeCreator interface {
+	BeforeCreate(ctx context.Context) error
+}
+
+// AfterCreator is called after an item has been inserted by Create, FindOrCreate, CreateMany, or BulkWriter.Insert.
+type AfterCreator interface {
+	AfterCreate(ctx context.Context) error
+}
+
+// BeforeUpdater is called before an item replaces a document by Replace, ReplaceDocument, Upsert,
+// FindOneAndReplace, or BulkWriter.Replace.
+type BeforeUpdater interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+// AfterFinder is called after an item has been read from the database by any typed find or iteration.
+// An error from AfterFind ends iteration and is returned.
+type AfterFinder interface {
+	AfterFind(ctx context.Context) error
+}
+
+// BeforeDeleter is called before items are deleted by Delete, DeleteAll, FindOneAndDelete, or BulkWriter.Delete.
+// The documents are not read, so it is called on a new zero item with the filter for the operation,
+// which is nil for DeleteAll.
+type BeforeDeleter interface {
+	BeforeDelete(ctx context.Context, filter bson.D) error
+}
+
+// beforeCreate calls the BeforeCreate hook of the item if it has one.
+func beforeCreate(ctx context.Context, item interface{}) error {
+	if hook, ok := item.(BeforeCreator); ok {
+		if err := hook.BeforeCreate(ctx); err != nil {
+			return fmt.Errorf("before create: %w", err)
+		}
+	}
+	return nil
+}
+
+// afterCreate calls the AfterCreate hook of the item if it has one.
+func afterCreate(ctx context.Context, item interface{}) error {
+	if hook, ok := item.(AfterCreator); ok {
+		if err := hook.AfterCreate(ctx); err != nil {
+			return fmt.Errorf("after create: %w", err)
+		}
+	}
+	return nil
+}
+
+// beforeUpdate calls the BeforeUpdate hook of the item if it has one.
+func beforeUpdate(ctx context.Context, item interface{}) error {
+	if hook, ok := item.(BeforeUpdater); ok {
+		if err := hook.BeforeUpdate(ctx); err != nil {
+			return fmt.Errorf("before update: %w", err)
+		}
+	}
+	return nil
+}
+
+// afterFind calls the AfterFind hook of the item if it has one.
+func afterFind(ctx context.Context, item interface{}) error {
+	if hook, ok := item.(AfterFinder); ok {
+		if err := hook.AfterFind(ctx); err != nil {
+			return fmt.Errorf("after find: %w", err)
+		}
+	}
+	return nil
+}
+
+// beforeDelete calls the BeforeDelete hook of the collection type if it has one.
+func beforeDelete[T any](ctx context.Context, filter bson.D) error {
+	if hook, ok := any(new(T)).(BeforeDeleter); ok {
+		if err := hook.BeforeDelete(ctx, filter); err != nil {
+			return fmt.Errorf("before delete: %w", err)
+		}
+	}
+	return nil
+}
+
+// decodeItem decodes a new item and calls its AfterFind hook.
+func decodeItem[T any](ctx context.Context, decode func(value interface{}) error) (*T, error) {
+	item := new(T)
+	if err := decode(item); err != nil {
+		return nil, fmt.Errorf("decode item: %w", err)
+	}
+	if err := afterFind(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,115 @@
+//go:build database
+
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type hooksDbTestSuite struct {
+	AccessTestSuite
+	hooked *TypedCollection[hookedItem]
+}
+
+func TestHooksDbSuite(t *testing.T) {
+	suite.Run(t, new(hooksDbTestSuite))
+}
+
+func (suite *hooksDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.hooked = ConnectTypedCollectionHelper[hookedItem](&suite.AccessTestSuite, testCollectionHooks)
+}
+
+func (suite *hooksDbTestSuite) TearDownTest() {
+	// The BeforeDelete hook refuses to delete all items.
+	suite.NoError(suite.hooked.Collection.DeleteAll())
+}
+
+func (suite *hooksDbTestSuite) TestCreate() {
+	item := &hookedItem{Alpha: "One", Bravo: 1}
+	suite.Require().NoError(suite.hooked.Create(item))
+	suite.Equal(2, item.Bravo)
+	suite.Equal(1, item.created)
+	found, err := suite.hooked.Find(bson.D{{Key: "bravo", Value: 2}})
+	suite.Require().NoError(err)
+	suite.Equal("one", found.Alpha)
+	suite.Equal(1, found.found)
+}
+
+func (suite *hooksDbTestSuite) TestCreateAbort() {
+	err := suite.hooked.Create(&hookedItem{Bravo: 1})
+	suite.ErrorIs(err, errHookAbort)
+	count, err := suite.hooked.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *hooksDbTestSuite) TestFindOrCreate() {
+	item := &hookedItem{Alpha: "one", Bravo: 1}
+	found, err := suite.hooked.FindOrCreate(bson.D{{Key: "alpha", Value: "one"}}, item)
+	suite.Require().NoError(err)
+	suite.Equal(2, found.Bravo)
+	suite.Equal(1, item.Bravo, "specified item not changed")
+	_, err = suite.hooked.FindOrCreate(bson.D{{Key: "alpha", Value: "two"}}, &hookedItem{})
+	suite.ErrorIs(err, errHookAbort)
+}
+
+func (suite *hooksDbTestSuite) TestReplaceAbort() {
+	item := &hookedItem{Alpha: "one", Bravo: 1}
+	suite.Require().NoError(suite.hooked.Create(item))
+	item.Alpha = ""
+	suite.ErrorIs(suite.hooked.Replace(bson.D{{Key: "bravo", Value: 2}}, item), errHookAbort)
+	_, err := suite.hooked.Upsert(bson.D{{Key: "bravo", Value: 2}}, item)
+	suite.ErrorIs(err, errHookAbort)
+	_, err = suite.hooked.FindOneAndReplace(bson.D{{Key: "bravo", Value: 2}}, item, options.After)
+	suite.ErrorIs(err, errHookAbort)
+	found, err := suite.hooked.Find(bson.D{{Key: "bravo", Value: 2}})
+	suite.Require().NoError(err)
+	suite.Equal("one", found.Alpha)
+}
+
+func (suite *hooksDbTestSuite) TestIterate() {
+	result, err := suite.hooked.CreateMany([]*hookedItem{{Alpha: "One"}, {Alpha: "TWO"}})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), result.Inserted)
+	alphas := make([]string, 0, 2)
+	suite.Require().NoError(suite.hooked.Iterate(NoFilter(), func(item *hookedItem) error {
+		suite.Equal(1, item.found)
+		alphas = append(alphas, item.Alpha)
+		return nil
+	}, options.Find().SetSort(bson.D{{Key: "alpha", Value: 1}})))
+	suite.Equal([]string{"one", "two"}, alphas)
+}
+
+func (suite *hooksDbTestSuite) TestBulk() {
+	items := []*hookedItem{{Alpha: "one"}, {Alpha: "two"}}
+	_, err := suite.hooked.Bulk().Insert(items...).Execute()
+	suite.Require().NoError(err)
+	for _, item := range items {
+		suite.Equal(1, item.Bravo)
+		suite.Equal(1, item.created)
+	}
+	_, err = suite.hooked.Bulk().Insert(&hookedItem{Alpha: "three"}, &hookedItem{}).Execute()
+	suite.ErrorIs(err, errHookAbort)
+	count, err := suite.hooked.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *hooksDbTestSuite) TestDelete() {
+	suite.Require().NoError(suite.hooked.Create(&hookedItem{Alpha: "one"}))
+	suite.ErrorIs(suite.hooked.DeleteAll(), errHookAbort)
+	_, err := suite.hooked.Bulk().Delete(nil).Execute()
+	suite.ErrorIs(err, errHookAbort)
+	count, err := suite.hooked.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+	deleted, err := suite.hooked.FindOneAndDelete(bson.D{{Key: "alpha", Value: "one"}})
+	suite.Require().NoError(err)
+	suite.Equal(1, deleted.found)
+	suite.NoError(suite.hooked.Delete(bson.D{{Key: "alpha", Value: "one"}}, true))
+}
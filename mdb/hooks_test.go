@@ -0,0 +1,122 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var errHookAbort = errors.New("hook abort")
+
+// hookedItem implements all lifecycle hooks.
+// Items without an alpha field can't be created or updated,
+// the alpha field is normalized to lower case when read,
+// and deleting all items is refused.
+type hookedItem struct {
+	Identity `bson:"inline"`
+	Alpha    string `bson:",omitempty"`
+	Bravo    int    `bson:",omitempty"`
+	created  int
+	found    int
+}
+
+func (hi *hookedItem) BeforeCreate(_ context.Context) error {
+	if hi.Alpha == "" {
+		return errHookAbort
+	}
+	hi.Bravo++
+	return nil
+}
+
+func (hi *hookedItem) AfterCreate(_ context.Context) error {
+	hi.created++
+	return nil
+}
+
+func (hi *hookedItem) BeforeUpdate(_ context.Context) error {
+	if hi.Alpha == "" {
+		return errHookAbort
+	}
+	return nil
+}
+
+func (hi *hookedItem) AfterFind(_ context.Context) error {
+	hi.Alpha = strings.ToLower(hi.Alpha)
+	hi.found++
+	return nil
+}
+
+func (hi *hookedItem) BeforeDelete(_ context.Context, filter bson.D) error {
+	if filter == nil {
+		return errHookAbort
+	}
+	return nil
+}
+
+type hooksTestSuite struct {
+	suite.Suite
+}
+
+func TestHooksSuite(t *testing.T) {
+	suite.Run(t, new(hooksTestSuite))
+}
+
+func (suite *hooksTestSuite) TestNoHooks() {
+	ctx := context.Background()
+	item := &SimpleItem{}
+	suite.NoError(beforeCreate(ctx, item))
+	suite.NoError(afterCreate(ctx, item))
+	suite.NoError(beforeUpdate(ctx, item))
+	suite.NoError(afterFind(ctx, item))
+	suite.NoError(beforeDelete[SimpleItem](ctx, nil))
+}
+
+func (suite *hooksTestSuite) TestCreateHooks() {
+	ctx := context.Background()
+	item := &hookedItem{Alpha: "one", Bravo: 1}
+	suite.NoError(beforeCreate(ctx, item))
+	suite.Equal(2, item.Bravo)
+	suite.NoError(afterCreate(ctx, item))
+	suite.Equal(1, item.created)
+	err := beforeCreate(ctx, &hookedItem{})
+	suite.ErrorIs(err, errHookAbort)
+	suite.Contains(err.Error(), "before create")
+}
+
+func (suite *hooksTestSuite) TestUpdateHook() {
+	ctx := context.Background()
+	suite.NoError(beforeUpdate(ctx, &hookedItem{Alpha: "one"}))
+	err := beforeUpdate(ctx, &hookedItem{})
+	suite.ErrorIs(err, errHookAbort)
+	suite.Contains(err.Error(), "before update")
+}
+
+func (suite *hooksTestSuite) TestDeleteHook() {
+	ctx := context.Background()
+	suite.NoError(beforeDelete[hookedItem](ctx, bson.D{{Key: "alpha", Value: "one"}}))
+	err := beforeDelete[hookedItem](ctx, nil)
+	suite.ErrorIs(err, errHookAbort)
+	suite.Contains(err.Error(), "before delete")
+}
+
+func (suite *hooksTestSuite) TestDecodeItem() {
+	ctx := context.Background()
+	raw, err := bson.Marshal(&hookedItem{Alpha: "ONE", Bravo: 1})
+	suite.Require().NoError(err)
+	item, err := decodeItem[hookedItem](ctx, func(value interface{}) error {
+		return bson.Unmarshal(raw, value)
+	})
+	suite.Require().NoError(err)
+	suite.Equal("one", item.Alpha)
+	suite.Equal(1, item.Bravo)
+	suite.Equal(1, item.found)
+	_, err = decodeItem[hookedItem](ctx, func(value interface{}) error {
+		return errHookAbort
+	})
+	suite.ErrorIs(err, errHookAbort)
+	suite.Contains(err.Error(), "decode item")
+}
@@ -2,31 +2,73 @@ package mdb
 
 import (
 	"fmt"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// IndexDescription describes an index by its key pattern and options.
 type IndexDescription struct {
-	unique bool
-	keys   []string
+	keys    bson.D
+	options *options.IndexOptions
 }
 
-// NewIndexDescription creates a new index description.
+// NewIndexDescription creates a new index description with ascending keys.
 func NewIndexDescription(unique bool, keys ...string) *IndexDescription {
+	keySpec := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		keySpec = append(keySpec, bson.E{Key: key, Value: 1})
+	}
+	return NewIndexDescriptionSpec(keySpec, options.Index().SetUnique(unique))
+}
+
+// NewIndexDescriptionSpec creates a new index description from a key pattern and index options.
+// The key pattern specifies the field order and the direction or type of each key,
+// for example bson.D{{"alpha", 1}, {"bravo", -1}}.
+func NewIndexDescriptionSpec(keys bson.D, opts ...*options.IndexOptions) *IndexDescription {
 	return &IndexDescription{
-		unique: unique,
-		keys:   keys,
+		keys:    keys,
+		options: options.MergeIndexOptions(opts...),
 	}
 }
 
+// NewTextIndexDescription creates a new text index description from field weights,
+// for example bson.D{{"title", 10}, {"body", 1}}.
+// A field with a higher weight contributes more to the text score of a match, the default weight is 1.
+// Options may be used to set the default language or the language override field.
+// A collection can have at most one text index.
+func NewTextIndexDescription(weights bson.D, opts ...*options.IndexOptions) *IndexDescription {
+	keySpec := make(bson.D, 0, len(weights))
+	for _, weight := range weights {
+		keySpec = append(keySpec, bson.E{Key: weight.Key, Value: textKeyType})
+	}
+	opts = append([]*options.IndexOptions{options.Index().SetWeights(weights)}, opts...)
+	return NewIndexDescriptionSpec(keySpec, opts...)
+}
+
+// AsBSON returns the key pattern for the index.
 func (id *IndexDescription) AsBSON() bson.D {
-	asBSON := bson.D{}
+	return id.keys
+}
+
+// Options returns the options for the index.
+func (id *IndexDescription) Options() *options.IndexOptions {
+	return id.options
+}
+
+// Name returns the name specified in the index options
+// or the name Mongo generates by default for this index.
+func (id *IndexDescription) Name() string {
+	if id.options != nil && id.options.Name != nil {
+		return *id.options.Name
+	}
+	parts := make([]string, 0, len(id.keys))
 	for _, key := range id.keys {
-		asBSON = append(asBSON, bson.E{Key: key, Value: 1})
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
 	}
-	return asBSON
+	return strings.Join(parts, "_")
 }
 
 // Finisher returns a function that can be used as a CollectionFinisher for creating this index.
@@ -39,12 +81,7 @@ func (id *IndexDescription) Finisher() CollectionFinisher {
 func (a *Access) Index(collection *Collection, description *IndexDescription) error {
 	ctx, cancel := a.ContextWithTimeout(a.config.Timeout.Index)
 	defer cancel()
-	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys: description.AsBSON(),
-		Options: &options.IndexOptions{
-			Unique: &description.unique,
-		},
-	})
+	_, err := collection.Indexes().CreateOne(ctx, indexModel(description))
 	if err != nil {
 		// TODO(mAdkins): at this point should the index be removed?
 		//  Experimentation suggests that double creation of the index is OK.
@@ -55,3 +92,11 @@ func (a *Access) Index(collection *Collection, description *IndexDescription) er
 
 	return nil
 }
+
+// indexModel returns the Mongo index model for the index description.
+func indexModel(description *IndexDescription) mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    description.AsBSON(),
+		Options: description.Options(),
+	}
+}
@@ -0,0 +1,224 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrIndexBuildInProgress is returned by IndexBuild.Wait when the wait ends
+// before the index build completes.
+var ErrIndexBuildInProgress = errors.New("index build in progress")
+
+// IndexBuildStatus describes the state of an index build.
+type IndexBuildStatus int
+
+const (
+	IndexBuilding IndexBuildStatus = iota
+	IndexReady
+	IndexFailed
+)
+
+func (ibs IndexBuildStatus) String() string {
+	switch ibs {
+	case IndexBuilding:
+		return "building"
+	case IndexReady:
+		return "ready"
+	case IndexFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("IndexBuildStatus(%d)", int(ibs))
+	}
+}
+
+// IndexBuild is a handle for an index build started by Access.IndexAsync.
+type IndexBuild struct {
+	access      *Access
+	collection  *Collection
+	description *IndexDescription
+	done        chan struct{}
+	err         error
+}
+
+// IndexAsync starts building an index and returns a handle for the build.
+// Unlike Index this is not limited by the Timeout.Index configuration,
+// the build runs until it is complete or the base context is cancelled.
+// Use the handle to check progress and wait for completion with a caller-provided context.
+func (a *Access) IndexAsync(collection *Collection, description *IndexDescription) *IndexBuild {
+	build := &IndexBuild{
+		access:      a,
+		collection:  collection,
+		description: description,
+		done:        make(chan struct{}),
+	}
+
+	go func() {
+		defer close(build.done)
+		_, err := collection.Indexes().CreateOne(a.Context(), indexModel(description))
+		if err != nil {
+			build.err = fmt.Errorf("create index %s: %w", description.Name(), err)
+		} else {
+			a.Info("Created index " + description.Name() + " on collection " + collection.Name())
+		}
+	}()
+
+	return build
+}
+
+// Name of the index being built.
+func (ib *IndexBuild) Name() string {
+	return ib.description.Name()
+}
+
+// Done returns a channel that is closed when the build request has completed.
+func (ib *IndexBuild) Done() <-chan struct{} {
+	return ib.done
+}
+
+// Err returns the error from a completed build request or nil if it has not completed.
+func (ib *IndexBuild) Err() error {
+	select {
+	case <-ib.done:
+		return ib.err
+	default:
+		return nil
+	}
+}
+
+// Status returns the current status of the build request.
+func (ib *IndexBuild) Status() IndexBuildStatus {
+	select {
+	case <-ib.done:
+		if ib.Err() != nil {
+			return IndexFailed
+		}
+		return IndexReady
+	default:
+		return IndexBuilding
+	}
+}
+
+// Wait for the build to complete or the context to be done.
+// Returns nil if the index is ready, the build error if the build failed,
+// or an error wrapping ErrIndexBuildInProgress if the context was done before the build completed.
+// The index may already be listed by the server while it is still being built
+// so only completion of the build request shows that it is ready to use.
+// The build itself is not cancelled by the context, Wait may be called again.
+func (ib *IndexBuild) Wait(ctx context.Context) error {
+	select {
+	case <-ib.done:
+		return ib.Err()
+	case <-ctx.Done():
+	}
+
+	// The build may have completed between the context being done and now.
+	if ib.Status() != IndexBuilding {
+		return ib.Err()
+	}
+
+	return fmt.Errorf("%w: %s (%s)", ErrIndexBuildInProgress, ib.Name(), ctx.Err())
+}
+
+// Progress returns the current progress of the index build as reported by currentOp.
+// Returns nil progress if there is no build operation running for this index.
+func (ib *IndexBuild) Progress(ctx context.Context) (*IndexProgress, error) {
+	return ib.access.IndexProgress(ctx, ib.collection, ib.Name())
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// IndexProgress describes the progress of an index build.
+type IndexProgress struct {
+	// Message describing the current phase of the build.
+	Message string
+
+	// Number of items processed in the current phase.
+	Done int64
+
+	// Total number of items to process in the current phase.
+	Total int64
+}
+
+// Percent returns the percentage of the current phase that is complete.
+func (ip *IndexProgress) Percent() float64 {
+	if ip.Total <= 0 {
+		return 0
+	}
+	return 100 * float64(ip.Done) / float64(ip.Total)
+}
+
+type currentOp struct {
+	Msg      string `bson:"msg"`
+	Progress struct {
+		Done  int64 `bson:"done"`
+		Total int64 `bson:"total"`
+	} `bson:"progress"`
+	Command struct {
+		Indexes []struct {
+			Name string `bson:"name"`
+		} `bson:"indexes"`
+	} `bson:"command"`
+}
+
+// progressFor returns progress from the first operation building the named index.
+// Returns nil if no operation is building the index.
+func progressFor(ops []currentOp, name string) *IndexProgress {
+	for _, op := range ops {
+		for _, index := range op.Command.Indexes {
+			if index.Name == name {
+				return &IndexProgress{
+					Message: op.Msg,
+					Done:    op.Progress.Done,
+					Total:   op.Progress.Total,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// IndexProgress returns the progress of a build for the named index on the collection.
+// Returns nil progress if there is no build operation running for the index.
+func (a *Access) IndexProgress(ctx context.Context, collection *Collection, name string) (*IndexProgress, error) {
+	var result struct {
+		InProgress []currentOp `bson:"inprog"`
+	}
+	err := a.client.Database("admin").RunCommand(ctx, bson.D{
+		{Key: "currentOp", Value: true},
+		{Key: "ns", Value: a.database.Name() + "." + collection.Name()},
+		{Key: "command.createIndexes", Value: bson.D{{Key: "$exists", Value: true}}},
+	}).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("current operations: %w", err)
+	}
+
+	return progressFor(result.InProgress, name), nil
+}
+
+// IndexExists checks to see if the named index exists on the collection.
+// An index that is still being built is listed by the server and reported as existing,
+// use IndexBuild.Wait to know when an index started by IndexAsync is ready.
+func (a *Access) IndexExists(collection *Collection, name string) (bool, error) {
+	ctx, cancel := a.ContextWithTimeout(a.config.Timeout.Index)
+	defer cancel()
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return false, fmt.Errorf("list indexes: %w", err)
+	}
+	var indexes []struct {
+		Name string `bson:"name"`
+	}
+	if err = cursor.All(ctx, &indexes); err != nil {
+		return false, fmt.Errorf("decode indexes: %w", err)
+	}
+	for _, index := range indexes {
+		if index.Name == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
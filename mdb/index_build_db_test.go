@@ -0,0 +1,86 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type indexBuildDbTestSuite struct {
+	AccessTestSuite
+	collection *Collection
+}
+
+func TestIndexBuildDbSuite(t *testing.T) {
+	suite.Run(t, new(indexBuildDbTestSuite))
+}
+
+func (suite *indexBuildDbTestSuite) SetupTest() {
+	suite.collection = suite.ConnectCollection(testCollectionValidation)
+}
+
+func (suite *indexBuildDbTestSuite) TearDownTest() {
+	_ = suite.collection.Drop()
+}
+
+func (suite *indexBuildDbTestSuite) TestIndexAsync() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	index := NewIndexDescription(true, "alpha")
+	build := suite.Access().IndexAsync(suite.collection, index)
+	suite.Require().NotNil(build)
+	suite.Equal(index.Name(), build.Name())
+	progress, err := build.Progress(context.Background())
+	suite.NoError(err)
+	if progress != nil {
+		suite.True(progress.Percent() >= 0)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	suite.Require().NoError(build.Wait(ctx))
+	suite.Equal(IndexReady, build.Status())
+	exists, err := suite.Access().IndexExists(suite.collection, index.Name())
+	suite.Require().NoError(err)
+	suite.True(exists)
+	NewIndexTester().TestIndexes(suite.T(), suite.collection, index)
+}
+
+func (suite *indexBuildDbTestSuite) TestIndexAsyncWaitExpired() {
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	build := suite.Access().IndexAsync(suite.collection, NewIndexDescription(false, "bravo"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if err := build.Wait(ctx); err != nil {
+		// The build may have finished before the wait.
+		suite.ErrorIs(err, ErrIndexBuildInProgress)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	suite.Require().NoError(build.Wait(ctx))
+	suite.Equal(IndexReady, build.Status())
+}
+
+func (suite *indexBuildDbTestSuite) TestIndexAsyncFailure() {
+	// Duplicate alpha values prevent building a unique index.
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(&SimpleItem{Alpha: "one", Bravo: 2, Charlie: "dupe"}))
+	build := suite.Access().IndexAsync(suite.collection, NewIndexDescription(true, "alpha"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	suite.Error(build.Wait(ctx))
+	suite.Equal(IndexFailed, build.Status())
+	exists, err := suite.Access().IndexExists(suite.collection, build.Name())
+	suite.Require().NoError(err)
+	suite.False(exists)
+}
+
+func (suite *indexBuildDbTestSuite) TestIndexExistsNone() {
+	exists, err := suite.Access().IndexExists(suite.collection, "goober_1")
+	suite.Require().NoError(err)
+	suite.False(exists)
+}
@@ -0,0 +1,81 @@
+package mdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type indexBuildTestSuite struct {
+	suite.Suite
+}
+
+func TestIndexBuildSuite(t *testing.T) {
+	suite.Run(t, new(indexBuildTestSuite))
+}
+
+func (suite *indexBuildTestSuite) TestProgressFor() {
+	var result struct {
+		InProgress []currentOp `bson:"inprog"`
+	}
+	raw, err := bson.Marshal(bson.M{
+		"inprog": bson.A{
+			bson.M{
+				"msg":      "Index Build: scanning collection",
+				"progress": bson.M{"done": 10, "total": 40},
+				"command": bson.M{
+					"createIndexes": "test-collection",
+					"indexes":       bson.A{bson.M{"name": "bravo_1"}},
+				},
+			},
+			bson.M{
+				"msg":      "Index Build: inserting keys from external sorter into index",
+				"progress": bson.M{"done": 30, "total": 40},
+				"command": bson.M{
+					"createIndexes": "test-collection",
+					"indexes":       bson.A{bson.M{"name": "alpha_1"}},
+				},
+			},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(bson.Unmarshal(raw, &result))
+	progress := progressFor(result.InProgress, "alpha_1")
+	suite.Require().NotNil(progress)
+	suite.Equal("Index Build: inserting keys from external sorter into index", progress.Message)
+	suite.Equal(int64(30), progress.Done)
+	suite.Equal(int64(40), progress.Total)
+	suite.Equal(75.0, progress.Percent())
+	suite.Nil(progressFor(result.InProgress, "charlie_1"))
+}
+
+func (suite *indexBuildTestSuite) TestPercentNoTotal() {
+	suite.Equal(0.0, (&IndexProgress{Done: 5}).Percent())
+}
+
+func (suite *indexBuildTestSuite) TestStatus() {
+	build := &IndexBuild{done: make(chan struct{})}
+	suite.Equal(IndexBuilding, build.Status())
+	suite.NoError(build.Err())
+	build.err = ErrIndexBuildInProgress
+	suite.NoError(build.Err()) // not visible until done
+	close(build.done)
+	suite.Equal(IndexFailed, build.Status())
+	suite.ErrorIs(build.Err(), ErrIndexBuildInProgress)
+	suite.Equal("failed", build.Status().String())
+}
+
+func (suite *indexBuildTestSuite) TestWaitExpired() {
+	build := &IndexBuild{description: NewIndexDescription(false, "alpha"), done: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := build.Wait(ctx)
+	suite.ErrorIs(err, ErrIndexBuildInProgress)
+	suite.Contains(err.Error(), "alpha_1")
+	suite.Contains(err.Error(), context.DeadlineExceeded.Error())
+	close(build.done)
+	suite.NoError(build.Wait(ctx))
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type indexTestSuite struct {
@@ -67,3 +69,27 @@ func (suite *indexTestSuite) TestIndexFinisher() {
 	suite.NotNil(collection)
 	NewIndexTester().TestIndexes(suite.T(), collection, index)
 }
+
+func (suite *indexTestSuite) TestIndexOptions() {
+	index := NewIndexDescriptionSpec(
+		bson.D{{Key: "alpha", Value: 1}, {Key: "bravo", Value: -1}},
+		options.Index().SetSparse(true).SetName("alpha-bravo"))
+	suite.Require().NoError(suite.Access().Index(suite.collection, index))
+	NewIndexTester().TestIndexes(suite.T(), suite.collection, index)
+}
+
+func (suite *indexTestSuite) TestIndexDiff() {
+	suite.Require().NoError(suite.Access().Index(suite.collection, NewIndexDescription(false, "alpha")))
+	suite.Require().NoError(suite.Access().Index(suite.collection, NewIndexDescription(true, "bravo")))
+	diff, err := suite.Access().CompareIndexes(suite.collection,
+		NewIndexDescription(true, "alpha"), NewIndexDescription(false, "charlie"))
+	suite.Require().NoError(err)
+	suite.Require().Len(diff.Changed, 1)
+	suite.Equal("alpha_1", diff.Changed[0].Actual.Name)
+	suite.Equal([]IndexOptionDiff{{Option: "unique", Expected: true}}, diff.Changed[0].Differences)
+	suite.Require().Len(diff.Missing, 1)
+	suite.Equal("charlie_1", diff.Missing[0].Name())
+	suite.Require().Len(diff.Extra, 1)
+	suite.Equal("bravo_1", diff.Extra[0].Name)
+	NewIndexTester().TestIndexesDiff(suite.T(), suite.collection, true, NewIndexDescription(false, "alpha"))
+}
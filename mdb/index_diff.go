@@ -0,0 +1,504 @@
+package mdb
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexSpec is an index specification as reported by the database.
+type IndexSpec struct {
+	// Name of the index.
+	Name string
+
+	// Key pattern of the index in field order.
+	Key bson.D
+
+	// Options contains all other fields of the specification except the index version.
+	Options bson.M
+}
+
+// IndexOptionDiff describes a single difference between an index description and an index.
+type IndexOptionDiff struct {
+	// Option name as reported by the database, or "key" for the key pattern.
+	Option string
+
+	// Expected value from the index description, nil if not specified.
+	Expected interface{}
+
+	// Actual value from the database, nil if not present.
+	Actual interface{}
+}
+
+func (iod IndexOptionDiff) String() string {
+	return fmt.Sprintf("%s expected %v actual %v", iod.Option, iod.Expected, iod.Actual)
+}
+
+// IndexChange describes an index description that does not match the corresponding index.
+type IndexChange struct {
+	Description *IndexDescription
+	Actual      *IndexSpec
+	Differences []IndexOptionDiff
+}
+
+// IndexDiff describes the differences between a set of index descriptions
+// and the indexes that exist on a collection.
+// The default _id_ index is never reported as extra.
+type IndexDiff struct {
+	// Missing index descriptions that have no matching index.
+	Missing []*IndexDescription
+
+	// Extra indexes that do not match any index description.
+	Extra []*IndexSpec
+
+	// Changed indexes that match an index description by key fields
+	// but differ in key order, direction, or options.
+	Changed []*IndexChange
+}
+
+// Empty returns true if there are no differences.
+func (id *IndexDiff) Empty() bool {
+	return len(id.Missing) == 0 && len(id.Extra) == 0 && len(id.Changed) == 0
+}
+
+func (id *IndexDiff) String() string {
+	lines := make([]string, 0, len(id.Missing)+len(id.Extra)+len(id.Changed))
+	for _, missing := range id.Missing {
+		lines = append(lines, fmt.Sprintf("missing index %s %v", missing.Name(), missing.AsBSON()))
+	}
+	for _, extra := range id.Extra {
+		lines = append(lines, fmt.Sprintf("extra index %s %v", extra.Name, extra.Key))
+	}
+	for _, changed := range id.Changed {
+		for _, diff := range changed.Differences {
+			lines = append(lines, fmt.Sprintf("changed index %s: %s", changed.Actual.Name, diff))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareIndexes compares index descriptions against the indexes on the collection.
+// Indexes are matched by key pattern rather than by name.
+// It is used by IndexTester and may also be used outside of tests, for example at deploy time.
+func (a *Access) CompareIndexes(collection *Collection, descriptions ...*IndexDescription) (*IndexDiff, error) {
+	specs, err := a.IndexSpecs(collection)
+	if err != nil {
+		return nil, err
+	}
+	return DiffIndexes(specs, descriptions...)
+}
+
+// IndexSpecs returns the specifications of all indexes on the collection.
+func (a *Access) IndexSpecs(collection *Collection) ([]*IndexSpec, error) {
+	ctx, cancel := a.ContextWithTimeout(a.config.Timeout.Index)
+	defer cancel()
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list indexes: %w", err)
+	}
+	var raws []bson.Raw
+	if err = cursor.All(ctx, &raws); err != nil {
+		return nil, fmt.Errorf("decode indexes: %w", err)
+	}
+	specs := make([]*IndexSpec, 0, len(raws))
+	for _, raw := range raws {
+		spec, err := parseIndexSpec(raw)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// DiffIndexes compares index descriptions against index specifications.
+func DiffIndexes(specs []*IndexSpec, descriptions ...*IndexDescription) (*IndexDiff, error) {
+	diff := &IndexDiff{}
+	matched := make(map[*IndexSpec]bool, len(specs))
+	for _, description := range descriptions {
+		expected, err := expectedIndexSpec(description)
+		if err != nil {
+			return nil, err
+		}
+		spec := findIndexSpec(specs, matched, expected.Key, keysEqual)
+		if spec == nil {
+			spec = findIndexSpec(specs, matched, expected.Key, keyFieldsEqual)
+		}
+		if spec == nil {
+			diff.Missing = append(diff.Missing, description)
+			continue
+		}
+		matched[spec] = true
+		if differences := compareIndexSpecs(expected, spec); len(differences) > 0 {
+			diff.Changed = append(diff.Changed, &IndexChange{
+				Description: description,
+				Actual:      spec,
+				Differences: differences,
+			})
+		}
+	}
+	for _, spec := range specs {
+		if !matched[spec] && spec.Name != idIndexName {
+			diff.Extra = append(diff.Extra, spec)
+		}
+	}
+	return diff, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// ignoredIndexFields are never compared.
+	ignoredIndexFields = map[string]bool{"v": true, "key": true, "name": true, "ns": true, "background": true}
+
+	// serverIndexFields are added by the server and only compared if specified in the description.
+	serverIndexFields = map[string]bool{"textIndexVersion": true, "2dsphereIndexVersion": true}
+
+	// falseIndexFields are boolean options that are equivalent to not being present when false.
+	falseIndexFields = map[string]bool{"unique": true, "sparse": true, "hidden": true}
+
+	// subsetIndexFields are documents filled in with defaults by the server,
+	// only fields specified in the description are compared.
+	subsetIndexFields = map[string]bool{"collation": true}
+)
+
+const (
+	textKeyFTS  = "_fts"
+	textKeyFTSX = "_ftsx"
+	textKeyType = "text"
+)
+
+func parseIndexSpec(raw bson.Raw) (*IndexSpec, error) {
+	var fields struct {
+		Name string `bson:"name"`
+		Key  bson.D `bson:"key"`
+	}
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal index specification: %w", err)
+	}
+	spec := &IndexSpec{Name: fields.Name, Key: fields.Key}
+	if err := bson.Unmarshal(raw, &spec.Options); err != nil {
+		return nil, fmt.Errorf("unmarshal index options: %w", err)
+	}
+	for field, value := range spec.Options {
+		if ignoredIndexFields[field] || (falseIndexFields[field] && value == false) {
+			delete(spec.Options, field)
+		}
+	}
+	return spec, nil
+}
+
+// expectedIndexSpec converts an index description into the specification the server would report.
+func expectedIndexSpec(description *IndexDescription) (*IndexSpec, error) {
+	opts := description.Options()
+	if opts == nil {
+		opts = options.Index()
+	}
+	expected := bson.M{}
+	setTrue := func(name string, value *bool) {
+		if value != nil && *value {
+			expected[name] = true
+		}
+	}
+	setValue := func(name string, value interface{}) {
+		if !isNilValue(value) {
+			expected[name] = value
+		}
+	}
+	setTrue("unique", opts.Unique)
+	setTrue("sparse", opts.Sparse)
+	setTrue("hidden", opts.Hidden)
+	setValue("expireAfterSeconds", opts.ExpireAfterSeconds)
+	setValue("name", opts.Name)
+	setValue("partialFilterExpression", opts.PartialFilterExpression)
+	setValue("storageEngine", opts.StorageEngine)
+	setValue("wildcardProjection", opts.WildcardProjection)
+	setValue("weights", opts.Weights)
+	setValue("default_language", opts.DefaultLanguage)
+	setValue("language_override", opts.LanguageOverride)
+	setValue("textIndexVersion", opts.TextVersion)
+	setValue("2dsphereIndexVersion", opts.SphereVersion)
+	setValue("bits", opts.Bits)
+	setValue("min", opts.Min)
+	setValue("max", opts.Max)
+	if opts.Collation != nil {
+		expected["collation"] = opts.Collation.ToDocument()
+	}
+
+	key := description.AsBSON()
+	if textFields := textIndexFields(key); len(textFields) > 0 {
+		key = textIndexKey(key)
+		weights := bson.M{}
+		for _, field := range textFields {
+			weights[field] = 1
+		}
+		if opts.Weights != nil {
+			specified, err := normalizeIndexValue(opts.Weights)
+			if err != nil {
+				return nil, fmt.Errorf("normalize text index weights: %w", err)
+			}
+			if specifiedMap, ok := specified.(bson.M); ok {
+				for field, weight := range specifiedMap {
+					weights[field] = weight
+				}
+			}
+		}
+		expected["weights"] = weights
+		if opts.DefaultLanguage == nil {
+			expected["default_language"] = "english"
+		}
+		if opts.LanguageOverride == nil {
+			expected["language_override"] = "language"
+		}
+	}
+
+	normalized, err := normalizeIndexValue(expected)
+	if err != nil {
+		return nil, fmt.Errorf("normalize index options: %w", err)
+	}
+	spec := &IndexSpec{Name: description.Name(), Key: key}
+	spec.Options, _ = normalized.(bson.M)
+	return spec, nil
+}
+
+// textIndexFields returns the fields in a key pattern that are part of a text index.
+func textIndexFields(key bson.D) []string {
+	var fields []string
+	for _, elem := range key {
+		if elem.Value == textKeyType {
+			fields = append(fields, elem.Key)
+		}
+	}
+	return fields
+}
+
+// textIndexKey converts the text fields in a key pattern to the form reported by the server.
+func textIndexKey(key bson.D) bson.D {
+	converted := make(bson.D, 0, len(key))
+	textAdded := false
+	for _, elem := range key {
+		if elem.Value != textKeyType {
+			converted = append(converted, elem)
+		} else if !textAdded {
+			converted = append(converted,
+				bson.E{Key: textKeyFTS, Value: textKeyType},
+				bson.E{Key: textKeyFTSX, Value: 1})
+			textAdded = true
+		}
+	}
+	return converted
+}
+
+func findIndexSpec(specs []*IndexSpec, matched map[*IndexSpec]bool, key bson.D, equal func(a, b bson.D) bool) *IndexSpec {
+	for _, spec := range specs {
+		if !matched[spec] && equal(key, spec.Key) {
+			return spec
+		}
+	}
+	return nil
+}
+
+// keysEqual compares key patterns including field order and direction.
+func keysEqual(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || !indexValuesEqual(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyFieldsEqual compares key patterns by field names only.
+func keyFieldsEqual(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	names := func(key bson.D) []string {
+		result := make([]string, 0, len(key))
+		for _, elem := range key {
+			result = append(result, elem.Key)
+		}
+		sort.Strings(result)
+		return result
+	}
+	return reflect.DeepEqual(names(a), names(b))
+}
+
+func compareIndexSpecs(expected, actual *IndexSpec) []IndexOptionDiff {
+	var differences []IndexOptionDiff
+	if !keysEqual(expected.Key, actual.Key) {
+		differences = append(differences, IndexOptionDiff{Option: "key", Expected: expected.Key, Actual: actual.Key})
+	}
+
+	// The name is only compared if it was specified.
+	expectedName, nameSpecified := expected.Options["name"]
+	if nameSpecified && expectedName != actual.Name {
+		differences = append(differences, IndexOptionDiff{Option: "name", Expected: expectedName, Actual: actual.Name})
+	}
+
+	actualOptions, err := normalizeIndexValue(actual.Options)
+	if err != nil {
+		// Fall back to comparing the raw options.
+		actualOptions = actual.Options
+	}
+	actualMap, _ := actualOptions.(bson.M)
+
+	fields := make([]string, 0, len(expected.Options)+len(actualMap))
+	for field := range expected.Options {
+		fields = append(fields, field)
+	}
+	for field := range actualMap {
+		if _, found := expected.Options[field]; !found {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if field == "name" || ignoredIndexFields[field] {
+			continue
+		}
+		expectedValue, inExpected := expected.Options[field]
+		actualValue := actualMap[field]
+		if !inExpected && serverIndexFields[field] {
+			continue
+		}
+		if subsetIndexFields[field] && inExpected {
+			if !indexValueSubset(expectedValue, actualValue) {
+				differences = append(differences, IndexOptionDiff{Option: field, Expected: expectedValue, Actual: actualValue})
+			}
+		} else if !indexValuesEqual(expectedValue, actualValue) {
+			differences = append(differences, IndexOptionDiff{Option: field, Expected: expectedValue, Actual: actualValue})
+		}
+	}
+	return differences
+}
+
+// isNilValue returns true for nil interfaces and nil pointers, maps, or slices within interfaces.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func indexValuesEqual(a, b interface{}) bool {
+	normalA, errA := normalizeIndexValue(a)
+	normalB, errB := normalizeIndexValue(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(normalA, normalB)
+}
+
+// indexValueSubset returns true if all fields in the expected document match the actual document.
+func indexValueSubset(expected, actual interface{}) bool {
+	expectedMap, okExpected := expected.(bson.M)
+	actualMap, okActual := actual.(bson.M)
+	if !okExpected || !okActual {
+		return indexValuesEqual(expected, actual)
+	}
+	for field, value := range expectedMap {
+		if !indexValuesEqual(value, actualMap[field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeIndexValue converts a value into a form that can be compared with reflect.DeepEqual.
+// Documents become bson.M, arrays become bson.A, and all numbers become float64.
+func normalizeIndexValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case *int32:
+		if v == nil {
+			return nil, nil
+		}
+		return float64(*v), nil
+	case *float64:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case bool, string:
+		return v, nil
+	case bson.M:
+		result := make(bson.M, len(v))
+		for key, item := range v {
+			normal, err := normalizeIndexValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = normal
+		}
+		return result, nil
+	case bson.D:
+		result := make(bson.M, len(v))
+		for _, elem := range v {
+			normal, err := normalizeIndexValue(elem.Value)
+			if err != nil {
+				return nil, err
+			}
+			result[elem.Key] = normal
+		}
+		return result, nil
+	case bson.A:
+		result := make(bson.A, 0, len(v))
+		for _, item := range v {
+			normal, err := normalizeIndexValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, normal)
+		}
+		return result, nil
+	case []interface{}:
+		return normalizeIndexValue(bson.A(v))
+	case primitive.Regex, primitive.DateTime, primitive.ObjectID:
+		return v, nil
+	default:
+		// Round trip through BSON to convert other documents and structs.
+		raw, err := bson.Marshal(bson.M{"v": value})
+		if err != nil {
+			return nil, fmt.Errorf("marshal %T: %w", value, err)
+		}
+		var wrapper bson.M
+		if err = bson.Unmarshal(raw, &wrapper); err != nil {
+			return nil, fmt.Errorf("unmarshal %T: %w", value, err)
+		}
+		if reflect.TypeOf(wrapper["v"]) == reflect.TypeOf(value) {
+			return value, nil
+		}
+		return normalizeIndexValue(wrapper["v"])
+	}
+}
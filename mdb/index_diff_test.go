@@ -0,0 +1,214 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type indexDiffTestSuite struct {
+	suite.Suite
+}
+
+func TestIndexDiffSuite(t *testing.T) {
+	suite.Run(t, new(indexDiffTestSuite))
+}
+
+var idIndexSpec = &IndexSpec{
+	Name:    "_id_",
+	Key:     bson.D{{Key: "_id", Value: int32(1)}},
+	Options: bson.M{},
+}
+
+func (suite *indexDiffTestSuite) TestDescriptionName() {
+	suite.Equal("alpha_1_bravo_1", NewIndexDescription(true, "alpha", "bravo").Name())
+	suite.Equal("alpha_1_bravo_-1", NewIndexDescriptionSpec(
+		bson.D{{Key: "alpha", Value: 1}, {Key: "bravo", Value: -1}}).Name())
+	suite.Equal("custom", NewIndexDescriptionSpec(
+		bson.D{{Key: "alpha", Value: 1}}, options.Index().SetName("custom")).Name())
+}
+
+func (suite *indexDiffTestSuite) TestMatch() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		idIndexSpec,
+		{
+			Name:    "alpha_1",
+			Key:     bson.D{{Key: "alpha", Value: int32(1)}},
+			Options: bson.M{"unique": true},
+		},
+		{
+			Name:    "whatever",
+			Key:     bson.D{{Key: "bravo", Value: int32(-1)}, {Key: "charlie", Value: 1.0}},
+			Options: bson.M{"sparse": true, "expireAfterSeconds": int64(60)},
+		},
+	},
+		NewIndexDescription(true, "alpha"),
+		NewIndexDescriptionSpec(bson.D{{Key: "bravo", Value: -1}, {Key: "charlie", Value: 1}},
+			options.Index().SetSparse(true).SetExpireAfterSeconds(60)))
+	suite.Require().NoError(err)
+	suite.True(diff.Empty(), diff.String())
+}
+
+func (suite *indexDiffTestSuite) TestMissingExtra() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		idIndexSpec,
+		{Name: "bravo_1", Key: bson.D{{Key: "bravo", Value: int32(1)}}, Options: bson.M{}},
+	}, NewIndexDescription(false, "alpha"))
+	suite.Require().NoError(err)
+	suite.False(diff.Empty())
+	suite.Require().Len(diff.Missing, 1)
+	suite.Equal("alpha_1", diff.Missing[0].Name())
+	suite.Require().Len(diff.Extra, 1)
+	suite.Equal("bravo_1", diff.Extra[0].Name)
+	suite.Empty(diff.Changed)
+	suite.Contains(diff.String(), "missing index alpha_1")
+	suite.Contains(diff.String(), "extra index bravo_1")
+}
+
+func (suite *indexDiffTestSuite) TestChangedOptions() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		{
+			Name:    "alpha_1",
+			Key:     bson.D{{Key: "alpha", Value: int32(1)}},
+			Options: bson.M{"sparse": true},
+		},
+	}, NewIndexDescription(true, "alpha"))
+	suite.Require().NoError(err)
+	suite.Empty(diff.Missing)
+	suite.Empty(diff.Extra)
+	suite.Require().Len(diff.Changed, 1)
+	suite.Equal([]IndexOptionDiff{
+		{Option: "sparse", Expected: nil, Actual: true},
+		{Option: "unique", Expected: true, Actual: nil},
+	}, diff.Changed[0].Differences)
+}
+
+func (suite *indexDiffTestSuite) TestChangedKeyOrderDirection() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		{
+			Name:    "bravo_1_alpha_-1",
+			Key:     bson.D{{Key: "bravo", Value: int32(1)}, {Key: "alpha", Value: int32(-1)}},
+			Options: bson.M{},
+		},
+	}, NewIndexDescription(false, "alpha", "bravo"))
+	suite.Require().NoError(err)
+	suite.Empty(diff.Missing)
+	suite.Empty(diff.Extra)
+	suite.Require().Len(diff.Changed, 1)
+	suite.Require().Len(diff.Changed[0].Differences, 1)
+	suite.Equal("key", diff.Changed[0].Differences[0].Option)
+}
+
+func (suite *indexDiffTestSuite) TestChangedName() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		{Name: "alpha_1", Key: bson.D{{Key: "alpha", Value: int32(1)}}, Options: bson.M{}},
+	}, NewIndexDescriptionSpec(bson.D{{Key: "alpha", Value: 1}}, options.Index().SetName("alpha")))
+	suite.Require().NoError(err)
+	suite.Require().Len(diff.Changed, 1)
+	suite.Equal([]IndexOptionDiff{{Option: "name", Expected: "alpha", Actual: "alpha_1"}}, diff.Changed[0].Differences)
+}
+
+func (suite *indexDiffTestSuite) TestTextIndex() {
+	diff, err := DiffIndexes([]*IndexSpec{
+		{
+			Name: "alpha_text_charlie_text",
+			Key:  bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+			Options: bson.M{
+				"weights":           bson.M{"alpha": int32(3), "charlie": int32(1)},
+				"default_language":  "english",
+				"language_override": "language",
+				"textIndexVersion":  int32(3),
+			},
+		},
+	}, NewIndexDescriptionSpec(bson.D{{Key: "alpha", Value: "text"}, {Key: "charlie", Value: "text"}},
+		options.Index().SetWeights(bson.M{"alpha": 3})))
+	suite.Require().NoError(err)
+	suite.True(diff.Empty(), diff.String())
+}
+
+func (suite *indexDiffTestSuite) TestTextIndexDescription() {
+	description := NewTextIndexDescription(bson.D{{Key: "alpha", Value: 3}, {Key: "charlie", Value: 1}},
+		options.Index().SetDefaultLanguage("spanish"))
+	suite.Equal(bson.D{{Key: "alpha", Value: "text"}, {Key: "charlie", Value: "text"}}, description.AsBSON())
+	suite.Equal("alpha_text_charlie_text", description.Name())
+	diff, err := DiffIndexes([]*IndexSpec{
+		{
+			Name: "alpha_text_charlie_text",
+			Key:  bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+			Options: bson.M{
+				"weights":           bson.M{"alpha": int32(3), "charlie": int32(1)},
+				"default_language":  "spanish",
+				"language_override": "language",
+				"textIndexVersion":  int32(3),
+			},
+		},
+	}, description)
+	suite.Require().NoError(err)
+	suite.True(diff.Empty(), diff.String())
+}
+
+func (suite *indexDiffTestSuite) TestGeoIndexDescription() {
+	description := NewGeoIndexDescription("location", "bravo")
+	suite.Equal("location_2dsphere_bravo_1", description.Name())
+	diff, err := DiffIndexes([]*IndexSpec{
+		{
+			Name:    "location_2dsphere_bravo_1",
+			Key:     bson.D{{Key: "location", Value: "2dsphere"}, {Key: "bravo", Value: int32(1)}},
+			Options: bson.M{"2dsphereIndexVersion": int32(3)},
+		},
+	}, description)
+	suite.Require().NoError(err)
+	suite.True(diff.Empty(), diff.String())
+}
+
+func (suite *indexDiffTestSuite) TestCollationSubset() {
+	actual := []*IndexSpec{
+		{
+			Name: "alpha_1",
+			Key:  bson.D{{Key: "alpha", Value: int32(1)}},
+			Options: bson.M{
+				"collation": bson.M{"locale": "en", "strength": int32(2), "caseLevel": false},
+			},
+		},
+	}
+	diff, err := DiffIndexes(actual, NewIndexDescriptionSpec(bson.D{{Key: "alpha", Value: 1}},
+		options.Index().SetCollation(&options.Collation{Locale: "en", Strength: 2})))
+	suite.Require().NoError(err)
+	suite.True(diff.Empty(), diff.String())
+	diff, err = DiffIndexes(actual, NewIndexDescriptionSpec(bson.D{{Key: "alpha", Value: 1}},
+		options.Index().SetCollation(&options.Collation{Locale: "fr"})))
+	suite.Require().NoError(err)
+	suite.Require().Len(diff.Changed, 1)
+	suite.Equal("collation", diff.Changed[0].Differences[0].Option)
+}
+
+func (suite *indexDiffTestSuite) TestParseIndexSpec() {
+	raw, err := bson.Marshal(bson.D{
+		{Key: "v", Value: 2},
+		{Key: "key", Value: bson.D{{Key: "alpha", Value: 1}, {Key: "bravo", Value: -1}}},
+		{Key: "name", Value: "alpha_1_bravo_-1"},
+		{Key: "unique", Value: true},
+	})
+	suite.Require().NoError(err)
+	spec, err := parseIndexSpec(raw)
+	suite.Require().NoError(err)
+	suite.Equal("alpha_1_bravo_-1", spec.Name)
+	suite.Equal(bson.D{{Key: "alpha", Value: int32(1)}, {Key: "bravo", Value: int32(-1)}}, spec.Key)
+	suite.Equal(bson.M{"unique": true}, spec.Options)
+}
+
+func (suite *indexDiffTestSuite) TestParseIndexSpecFalse() {
+	raw, err := bson.Marshal(bson.D{
+		{Key: "v", Value: 2},
+		{Key: "key", Value: bson.D{{Key: "alpha", Value: 1}}},
+		{Key: "name", Value: "alpha_1"},
+		{Key: "unique", Value: false},
+		{Key: "sparse", Value: true},
+	})
+	suite.Require().NoError(err)
+	spec, err := parseIndexSpec(raw)
+	suite.Require().NoError(err)
+	suite.Equal(bson.M{"sparse": true}, spec.Options)
+}
@@ -1,8 +1,6 @@
 package mdb
 
 import (
-	"context"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,39 +20,24 @@ func NewIndexTester() IndexTester {
 	return make(IndexTester, 0, 2)
 }
 
+// TestIndexes verifies that the collection has indexes matching the descriptions
+// and no other indexes except the default _id_ index.
+// Indexes are matched by key pattern and compared for key order, direction, and all options.
 func (it IndexTester) TestIndexes(t *testing.T, collection *Collection, descriptions ...*IndexDescription) {
-	ctx := context.Background()
-	cursor, err := collection.Indexes().List(ctx)
-	require.NoError(t, err)
-	err = cursor.All(ctx, &it)
-	require.NoError(t, err)
-	assert.Len(t, it, len(descriptions)+1)
-	it.hasIndexNamed(t, "_id_", NewIndexDescription(false, "_id"))
-	for _, description := range descriptions {
-		nameMap := make([]string, 0, len(description.keys))
-		for _, key := range description.keys {
-			nameMap = append(nameMap, key+"_1")
-		}
-		it.hasIndexNamed(t, strings.Join(nameMap, "_"), description)
-	}
+	it.TestIndexesDiff(t, collection, false, descriptions...)
 }
 
-func (it IndexTester) hasIndexNamed(t *testing.T, name string, description *IndexDescription) {
-	for _, data := range it {
-		if data.Name == name {
-			assert.Equal(t, description.unique, data.Unique, "check unique for index %s", name)
-			keyMap := make(map[string]int32, len(description.keys))
-			for _, key := range description.keys {
-				keyMap[key] = 1
-			}
-			assert.Equal(t, keyMap, data.Key, "check keys for index %s", name)
-			return
-		}
-	}
-
-	names := make([]string, 0, len(it))
-	for _, data := range it {
-		names = append(names, data.Name)
+// TestIndexesDiff verifies that the collection has indexes matching the descriptions
+// as for TestIndexes, ignoring indexes that are not described if allowExtra is set.
+// Returns the differences for any further checks.
+func (it IndexTester) TestIndexesDiff(
+	t *testing.T, collection *Collection, allowExtra bool, descriptions ...*IndexDescription) *IndexDiff {
+	diff, err := collection.Access.CompareIndexes(collection, descriptions...)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	if allowExtra {
+		diff.Extra = nil
 	}
-	assert.Fail(t, "missing index", "no index %s (%s)", name, strings.Join(names, ", "))
+	assert.True(t, diff.Empty(), "index differences:\n%s", diff)
+	return diff
 }
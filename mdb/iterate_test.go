@@ -0,0 +1,73 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type iterateTestSuite struct {
+	suite.Suite
+}
+
+func TestIterateSuite(t *testing.T) {
+	suite.Run(t, new(iterateTestSuite))
+}
+
+func (suite *iterateTestSuite) TestAll() {
+	var alpha []string
+	suite.NoError(iterateCursor(context.Background(), suite.cursor(nil), suite.collect(&alpha, "")))
+	suite.Equal([]string{"one", "two", "three"}, alpha)
+}
+
+func (suite *iterateTestSuite) TestStopIteration() {
+	var alpha []string
+	suite.NoError(iterateCursor(context.Background(), suite.cursor(nil), suite.collect(&alpha, "two")))
+	suite.Equal([]string{"one", "two"}, alpha)
+}
+
+func (suite *iterateTestSuite) TestFunctionError() {
+	failure := errors.New("failure")
+	count := 0
+	err := iterateCursor(context.Background(), suite.cursor(nil), func(cursor *mongo.Cursor) error {
+		count++
+		return failure
+	})
+	suite.ErrorIs(err, failure)
+	suite.Equal(1, count)
+}
+
+func (suite *iterateTestSuite) TestCursorError() {
+	failure := errors.New("cursor failure")
+	var alpha []string
+	err := iterateCursor(context.Background(), suite.cursor(failure), suite.collect(&alpha, ""))
+	suite.ErrorIs(err, failure)
+}
+
+func (suite *iterateTestSuite) collect(alpha *[]string, stopAfter string) func(cursor *mongo.Cursor) error {
+	return func(cursor *mongo.Cursor) error {
+		item := new(SimpleItem)
+		if err := cursor.Decode(item); err != nil {
+			return err
+		}
+		*alpha = append(*alpha, item.Alpha)
+		if item.Alpha == stopAfter {
+			return StopIteration
+		}
+		return nil
+	}
+}
+
+func (suite *iterateTestSuite) cursor(err error) *mongo.Cursor {
+	cursor, cursorErr := mongo.NewCursorFromDocuments([]interface{}{
+		bson.D{{Key: "alpha", Value: "one"}},
+		bson.D{{Key: "alpha", Value: "two"}},
+		bson.D{{Key: "alpha", Value: "three"}},
+	}, err, nil)
+	suite.Require().NoError(cursorErr)
+	return cursor
+}
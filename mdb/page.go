@@ -0,0 +1,260 @@
+package mdb
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidPageToken is returned by FindPage when the continuation token can't be used.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+var errInvalidPageLimit = errors.New("page limit must be positive")
+
+// Page is a single page of items returned by FindPage.
+type Page[T any] struct {
+	// Items on the page in sort order.
+	Items []*T
+
+	// Next is the token for the following page, empty if there are no more items.
+	Next string
+
+	// Previous is the token for the preceding page, empty if this is the first page.
+	Previous string
+}
+
+// pageToken contains the sort key values for the item at the edge of a page.
+// The sort keys and directions are included so the token is only used with the same sort.
+type pageToken struct {
+	Keys       []string        `bson:"k"`
+	Directions []int           `bson:"d"`
+	Values     []bson.RawValue `bson:"v"`
+	Backward   bool            `bson:"b,omitempty"`
+}
+
+// newPageToken returns a token for the sort and the sort key values of an edge item.
+func newPageToken(sort bson.D, values []bson.RawValue, backward bool) *pageToken {
+	return &pageToken{Keys: sortKeys(sort), Directions: sortDirections(sort), Values: values, Backward: backward}
+}
+
+func (pt *pageToken) encode() (string, error) {
+	raw, err := bson.Marshal(pt)
+	if err != nil {
+		return "", fmt.Errorf("marshal page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodePageToken(token string, sort bson.D) (*pageToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, err)
+	}
+	decoded := new(pageToken)
+	if err = bson.Unmarshal(raw, decoded); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, err)
+	}
+	if !reflect.DeepEqual(decoded.Keys, sortKeys(sort)) ||
+		!reflect.DeepEqual(decoded.Directions, sortDirections(sort)) ||
+		len(decoded.Values) != len(sort) {
+		return nil, fmt.Errorf("%w: sort does not match", ErrInvalidPageToken)
+	}
+	return decoded, nil
+}
+
+// pageSort returns the sort with _id added as a final tie-breaker if it isn't already present.
+func pageSort(sort bson.D) bson.D {
+	result := make(bson.D, 0, len(sort)+1)
+	for _, elem := range sort {
+		if elem.Key == "_id" {
+			return append(result, sort...)
+		}
+	}
+	result = append(result, sort...)
+	return append(result, bson.E{Key: "_id", Value: 1})
+}
+
+func sortKeys(sort bson.D) []string {
+	keys := make([]string, 0, len(sort))
+	for _, elem := range sort {
+		keys = append(keys, elem.Key)
+	}
+	return keys
+}
+
+// sortDirections returns 1 for each ascending sort key and -1 for each descending one.
+func sortDirections(sort bson.D) []int {
+	directions := make([]int, 0, len(sort))
+	for _, elem := range sort {
+		if sortDescending(elem.Value) {
+			directions = append(directions, -1)
+		} else {
+			directions = append(directions, 1)
+		}
+	}
+	return directions
+}
+
+// sortDescending returns true if the sort direction is descending.
+func sortDescending(direction interface{}) bool {
+	switch d := direction.(type) {
+	case int:
+		return d < 0
+	case int32:
+		return d < 0
+	case int64:
+		return d < 0
+	case float64:
+		return d < 0
+	default:
+		return false
+	}
+}
+
+// reverseSort returns the sort with all directions reversed.
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, 0, len(sort))
+	for _, elem := range sort {
+		direction := 1
+		if !sortDescending(elem.Value) {
+			direction = -1
+		}
+		reversed = append(reversed, bson.E{Key: elem.Key, Value: direction})
+	}
+	return reversed
+}
+
+// keysetFilter returns a filter for items after the specified values in the sort order.
+// For sort keys k1, k2 and values v1, v2 this is
+//
+//	{$or: [{k1: {$gt: v1}}, {k1: v1, k2: {$gt: v2}}]}
+//
+// with $lt used instead of $gt for descending keys.
+func keysetFilter(sort bson.D, values []bson.RawValue) bson.D {
+	clauses := make(bson.A, 0, len(sort))
+	for i, elem := range sort {
+		clause := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			clause = append(clause, bson.E{Key: sort[j].Key, Value: values[j]})
+		}
+		operator := "$gt"
+		if sortDescending(elem.Value) {
+			operator = "$lt"
+		}
+		clause = append(clause, bson.E{Key: elem.Key, Value: bson.D{{Key: operator, Value: values[i]}}})
+		clauses = append(clauses, clause)
+	}
+	return bson.D{{Key: "$or", Value: clauses}}
+}
+
+// sortValues returns the values of the sort keys from a document.
+// Missing fields are returned as null.
+func sortValues(document bson.Raw, sort bson.D) []bson.RawValue {
+	values := make([]bson.RawValue, 0, len(sort))
+	for _, elem := range sort {
+		value, err := document.LookupErr(strings.Split(elem.Key, ".")...)
+		if err != nil {
+			value = bson.RawValue{Type: bsontype.Null}
+		} else {
+			// Copy the value so it doesn't refer to cursor memory that may be reused.
+			value.Value = append([]byte(nil), value.Value...)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// FindPage returns a page of items matching the filter using keyset pagination.
+// Items are sorted by the specified sort with _id added as a final tie-breaker.
+// An empty token returns the first page, otherwise use the Next or Previous token
+// from a page returned by an earlier call with the same filter and sort.
+// Since pages are located by sort key values rather than by position,
+// paging remains stable while items are concurrently inserted.
+// Sort keys should be present in every item as null or missing values
+// can't be compared with values of other types when locating a page.
+func (c *TypedCollection[T]) FindPage(filter, sort bson.D, limit int, token string) (*Page[T], error) {
+	if limit < 1 {
+		return nil, c.opError("find page", filter, errInvalidPageLimit)
+	}
+	if filter == nil {
+		filter = NoFilter()
+	}
+	sort = pageSort(sort)
+
+	var start *pageToken
+	if token != "" {
+		var err error
+		if start, err = decodePageToken(token, sort); err != nil {
+			return nil, c.opError("find page", filter, err)
+		}
+	}
+	backward := start != nil && start.Backward
+
+	var query interface{} = c.activeFilter(filter)
+	querySort := sort
+	if backward {
+		querySort = reverseSort(sort)
+	}
+	if start != nil {
+		query = bson.D{{Key: "$and", Value: bson.A{query, keysetFilter(querySort, start.Values)}}}
+	}
+
+	// Fetch an extra item to know if there are any more in this direction.
+	opts := options.Find().SetSort(querySort).SetLimit(int64(limit) + 1)
+	cursor, err := c.Collection.Collection.Find(c.ctx, query, opts)
+	if err != nil {
+		return nil, c.opError("find page", filter, err)
+	}
+	defer func() { _ = cursor.Close(c.ctx) }()
+
+	items := make([]*T, 0, limit+1)
+	edges := make([][]bson.RawValue, 0, limit+1)
+	for cursor.Next(c.ctx) {
+		item, err := decodeItem[T](c.ctx, cursor.Decode)
+		if err != nil {
+			return nil, c.opError("find page", filter, err)
+		}
+		items = append(items, item)
+		edges = append(edges, sortValues(cursor.Current, sort))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, c.opError("find page", filter, fmt.Errorf("iterate items: %w", err))
+	}
+
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+		edges = edges[:limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+			edges[i], edges[j] = edges[j], edges[i]
+		}
+	}
+
+	page := &Page[T]{Items: items}
+	if len(items) < 1 {
+		return page, nil
+	}
+	if backward || more {
+		last := newPageToken(sort, edges[len(edges)-1], false)
+		if page.Next, err = last.encode(); err != nil {
+			return nil, c.opError("find page", filter, err)
+		}
+	}
+	if (backward && more) || (!backward && start != nil) {
+		first := newPageToken(sort, edges[0], true)
+		if page.Previous, err = first.encode(); err != nil {
+			return nil, c.opError("find page", filter, err)
+		}
+	}
+
+	return page, nil
+}
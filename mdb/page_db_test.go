@@ -0,0 +1,117 @@
+//go:build database
+
+package mdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type pageDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[SimpleItem]
+}
+
+func TestPageDbSuite(t *testing.T) {
+	suite.Run(t, new(pageDbTestSuite))
+}
+
+func (suite *pageDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollection)
+}
+
+func (suite *pageDbTestSuite) SetupTest() {
+	for i := 0; i < 10; i++ {
+		suite.Require().NoError(suite.typed.Create(&SimpleItem{
+			Alpha:   fmt.Sprintf("Alpha #%d", i),
+			Bravo:   i/2 + 1, // duplicate sort values exercise the _id tie-breaker
+			Charlie: "Paged",
+		}))
+	}
+}
+
+func (suite *pageDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *pageDbTestSuite) TestForwardBackward() {
+	sort := bson.D{{Key: "bravo", Value: -1}}
+	var forward []string
+	var tokens []string
+	token := ""
+	for {
+		page, err := suite.typed.FindPage(NoFilter(), sort, 3, token)
+		suite.Require().NoError(err)
+		tokens = append(tokens, page.Previous)
+		for _, item := range page.Items {
+			forward = append(forward, item.Alpha)
+		}
+		if page.Next == "" {
+			break
+		}
+		token = page.Next
+	}
+	suite.Len(forward, 10)
+	suite.Equal("", tokens[0])
+	suite.Len(tokens, 4)
+
+	// Page backward from the last page.
+	page, err := suite.typed.FindPage(NoFilter(), sort, 3, tokens[3])
+	suite.Require().NoError(err)
+	suite.Require().Len(page.Items, 3)
+	suite.Equal(forward[6:9], suite.alphas(page.Items))
+	suite.NotEmpty(page.Previous)
+	suite.NotEmpty(page.Next)
+	page, err = suite.typed.FindPage(NoFilter(), sort, 3, page.Previous)
+	suite.Require().NoError(err)
+	suite.Equal(forward[3:6], suite.alphas(page.Items))
+	page, err = suite.typed.FindPage(NoFilter(), sort, 3, page.Previous)
+	suite.Require().NoError(err)
+	suite.Equal(forward[0:3], suite.alphas(page.Items))
+	suite.Empty(page.Previous)
+}
+
+func (suite *pageDbTestSuite) TestConcurrentInsert() {
+	sort := bson.D{{Key: "alpha", Value: 1}}
+	page, err := suite.typed.FindPage(NoFilter(), sort, 4, "")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"Alpha #0", "Alpha #1", "Alpha #2", "Alpha #3"}, suite.alphas(page.Items))
+	// Insert an item before the current position, it should not shift the next page.
+	suite.Require().NoError(suite.typed.Create(&SimpleItem{Alpha: "Alpha #00", Charlie: "Inserted"}))
+	page, err = suite.typed.FindPage(NoFilter(), sort, 4, page.Next)
+	suite.Require().NoError(err)
+	suite.Equal([]string{"Alpha #4", "Alpha #5", "Alpha #6", "Alpha #7"}, suite.alphas(page.Items))
+}
+
+func (suite *pageDbTestSuite) TestFiltered() {
+	page, err := suite.typed.FindPage(bson.D{{Key: "bravo", Value: 2}}, nil, 5, "")
+	suite.Require().NoError(err)
+	suite.Len(page.Items, 2)
+	suite.Empty(page.Next)
+	suite.Empty(page.Previous)
+}
+
+func (suite *pageDbTestSuite) TestInvalid() {
+	_, err := suite.typed.FindPage(NoFilter(), nil, 0, "")
+	var opErr *OpError
+	suite.Require().ErrorAs(err, &opErr)
+	suite.Equal("find page", opErr.Op)
+	page, err := suite.typed.FindPage(NoFilter(), bson.D{{Key: "alpha", Value: 1}}, 2, "")
+	suite.Require().NoError(err)
+	_, err = suite.typed.FindPage(NoFilter(), bson.D{{Key: "bravo", Value: 1}}, 2, page.Next)
+	suite.ErrorIs(err, ErrInvalidPageToken)
+	_, err = suite.typed.FindPage(NoFilter(), bson.D{{Key: "alpha", Value: -1}}, 2, page.Next)
+	suite.ErrorIs(err, ErrInvalidPageToken)
+}
+
+func (suite *pageDbTestSuite) alphas(items []*SimpleItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Alpha)
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+type pageTestSuite struct {
+	suite.Suite
+}
+
+func TestPageSuite(t *testing.T) {
+	suite.Run(t, new(pageTestSuite))
+}
+
+func (suite *pageTestSuite) TestPageSort() {
+	suite.Equal(bson.D{{Key: "bravo", Value: -1}, {Key: "_id", Value: 1}},
+		pageSort(bson.D{{Key: "bravo", Value: -1}}))
+	suite.Equal(bson.D{{Key: "_id", Value: -1}}, pageSort(bson.D{{Key: "_id", Value: -1}}))
+	suite.Equal(bson.D{{Key: "_id", Value: 1}}, pageSort(nil))
+}
+
+func (suite *pageTestSuite) TestReverseSort() {
+	suite.Equal(bson.D{{Key: "bravo", Value: 1}, {Key: "_id", Value: -1}},
+		reverseSort(bson.D{{Key: "bravo", Value: int32(-1)}, {Key: "_id", Value: 1}}))
+}
+
+func (suite *pageTestSuite) TestKeysetFilter() {
+	values := suite.values(bson.D{{Key: "bravo", Value: 3}, {Key: "_id", Value: "x"}},
+		bson.D{{Key: "bravo", Value: -1}, {Key: "_id", Value: 1}})
+	filter := keysetFilter(bson.D{{Key: "bravo", Value: -1}, {Key: "_id", Value: 1}}, values)
+	suite.Equal(bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "bravo", Value: bson.D{{Key: "$lt", Value: values[0]}}}},
+		bson.D{
+			{Key: "bravo", Value: values[0]},
+			{Key: "_id", Value: bson.D{{Key: "$gt", Value: values[1]}}},
+		},
+	}}}, filter)
+}
+
+func (suite *pageTestSuite) TestSortValues() {
+	values := suite.values(
+		bson.D{{Key: "alpha", Value: bson.D{{Key: "nested", Value: "n"}}}, {Key: "_id", Value: 7}},
+		bson.D{{Key: "alpha.nested", Value: 1}, {Key: "missing", Value: 1}, {Key: "_id", Value: 1}})
+	suite.Require().Len(values, 3)
+	suite.Equal("n", values[0].StringValue())
+	suite.Equal(bsontype.Null, values[1].Type)
+	suite.Equal(int32(7), values[2].Int32())
+}
+
+func (suite *pageTestSuite) TestTokenRoundTrip() {
+	sort := bson.D{{Key: "bravo", Value: 1}, {Key: "_id", Value: 1}}
+	token := newPageToken(sort,
+		suite.values(bson.D{{Key: "bravo", Value: 2}, {Key: "_id", Value: "two"}}, sort), true)
+	encoded, err := token.encode()
+	suite.Require().NoError(err)
+	decoded, err := decodePageToken(encoded, sort)
+	suite.Require().NoError(err)
+	suite.True(decoded.Backward)
+	suite.Equal(sortKeys(sort), decoded.Keys)
+	suite.Equal([]int{1, 1}, decoded.Directions)
+	suite.Require().Len(decoded.Values, 2)
+	suite.Equal(int32(2), decoded.Values[0].Int32())
+	suite.Equal("two", decoded.Values[1].StringValue())
+}
+
+func (suite *pageTestSuite) TestTokenInvalid() {
+	sort := bson.D{{Key: "bravo", Value: 1}, {Key: "_id", Value: 1}}
+	_, err := decodePageToken("not a token!", sort)
+	suite.ErrorIs(err, ErrInvalidPageToken)
+	token := &pageToken{
+		Keys:       []string{"alpha", "_id"},
+		Directions: []int{1, 1},
+		Values:     suite.values(bson.D{{Key: "alpha", Value: "a"}, {Key: "_id", Value: 1}}, sort),
+	}
+	encoded, err := token.encode()
+	suite.Require().NoError(err)
+	_, err = decodePageToken(encoded, sort)
+	suite.ErrorIs(err, ErrInvalidPageToken)
+	// Same keys in a different direction:
+	token = newPageToken(sort, suite.values(bson.D{{Key: "bravo", Value: 2}, {Key: "_id", Value: 1}}, sort), false)
+	encoded, err = token.encode()
+	suite.Require().NoError(err)
+	_, err = decodePageToken(encoded, bson.D{{Key: "bravo", Value: -1}, {Key: "_id", Value: 1}})
+	suite.ErrorIs(err, ErrInvalidPageToken)
+}
+
+func (suite *pageTestSuite) TestSortDirections() {
+	suite.Equal([]int{1, -1, -1, 1},
+		sortDirections(bson.D{
+			{Key: "a", Value: 1}, {Key: "b", Value: -1}, {Key: "c", Value: int64(-1)}, {Key: "d", Value: int32(1)},
+		}))
+}
+
+func (suite *pageTestSuite) values(document, sort bson.D) []bson.RawValue {
+	raw, err := bson.Marshal(document)
+	suite.Require().NoError(err)
+	return sortValues(raw, sort)
+}
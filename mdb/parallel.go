@@ -0,0 +1,157 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ParallelOptions configures IterateParallel.
+type ParallelOptions[T any] struct {
+	// Find options for the cursor such as sort, projection, or batch size.
+	Find *options.FindOptions
+
+	// Key returns an ordering key for an item.
+	// If nil, items may be processed in any order.
+	// Otherwise, items with the same key are processed one at a time in cursor order.
+	// Returning the same key for every item processes all items in cursor order.
+	Key func(item *T) string
+
+	// Buffer is the number of items that may be queued for each worker, default 1.
+	Buffer int
+}
+
+// IterateParallel applies the function to each item matching the filter using a pool of workers.
+// A single goroutine reads the cursor and feeds items to the workers.
+// The first error returned by the function cancels the context passed to the other workers,
+// stops reading the cursor, and is returned after all workers have finished.
+// Return StopIteration from the function to end iteration early without an error.
+// Each item is decoded into a new object so the function may keep it.
+func (c *TypedCollection[T]) IterateParallel(
+	ctx context.Context, filter bson.D, workers int,
+	fn func(ctx context.Context, item *T) error, opts ...*ParallelOptions[T]) error {
+	if workers < 1 {
+		workers = 1
+	}
+	opt := &ParallelOptions[T]{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	buffer := opt.Buffer
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	var findOpts []*options.FindOptions
+	if opt.Find != nil {
+		findOpts = append(findOpts, opt.Find)
+	}
+	cursor, err := c.Collection.Collection.Find(ctx, c.activeFilter(filter), findOpts...)
+	if err != nil {
+		return c.opError("iterate parallel", filter, err)
+	}
+
+	return c.opError("iterate parallel", filter, iterateParallel(ctx, cursor, workers, buffer, opt.Key, fn))
+}
+
+// iterateParallel feeds the documents in the cursor to a pool of workers applying the function.
+// The cursor is always closed.
+func iterateParallel[T any](
+	ctx context.Context, cursor *mongo.Cursor, workers, buffer int,
+	key func(item *T) string, fn func(ctx context.Context, item *T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer func() { _ = cursor.Close(context.Background()) }()
+
+	var firstErr error
+	var stopped bool
+	var errLock sync.Mutex
+	fail := func(err error) {
+		errLock.Lock()
+		defer errLock.Unlock()
+		if firstErr == nil && !stopped {
+			if errors.Is(err, StopIteration) {
+				stopped = true
+			} else {
+				firstErr = err
+			}
+		}
+		cancel()
+	}
+
+	// Without a key all workers share a queue, otherwise each worker has its own.
+	queueCount := 1
+	if key != nil {
+		queueCount = workers
+	}
+	queues := make([]chan *T, queueCount)
+	for i := range queues {
+		queues[i] = make(chan *T, buffer)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		queue := queues[i%queueCount]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if ctx.Err() != nil {
+					continue // drain the queue after cancellation
+				}
+				if err := fn(ctx, item); err != nil {
+					fail(fmt.Errorf("apply function: %w", err))
+				}
+			}
+		}()
+	}
+
+	readErr := func() error {
+		for cursor.Next(ctx) {
+			item, err := decodeItem[T](ctx, cursor.Decode)
+			if err != nil {
+				return err
+			}
+			queue := queues[0]
+			if key != nil {
+				queue = queues[queueIndex(key(item), queueCount)]
+			}
+			select {
+			case queue <- item:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return cursor.Err()
+	}()
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+
+	errLock.Lock()
+	defer errLock.Unlock()
+	if firstErr != nil {
+		return firstErr
+	} else if stopped {
+		return nil
+	} else if readErr != nil {
+		return fmt.Errorf("read items: %w", readErr)
+	}
+
+	return nil
+}
+
+// queueIndex returns the queue for a key so that the same key always uses the same queue.
+func queueIndex(key string, queueCount int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(queueCount))
+}
@@ -0,0 +1,145 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type parallelTestSuite struct {
+	suite.Suite
+}
+
+func TestParallelSuite(t *testing.T) {
+	suite.Run(t, new(parallelTestSuite))
+}
+
+func (suite *parallelTestSuite) TestAll() {
+	var lock sync.Mutex
+	var alpha []string
+	suite.NoError(iterateParallel(context.Background(), suite.cursor(100, nil), 4, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			lock.Lock()
+			defer lock.Unlock()
+			alpha = append(alpha, item.Alpha)
+			return nil
+		}))
+	suite.Len(alpha, 100)
+	sort.Strings(alpha)
+	suite.Equal("Alpha #000", alpha[0])
+	suite.Equal("Alpha #099", alpha[99])
+}
+
+func (suite *parallelTestSuite) TestWorkerLimit() {
+	var active, most int32
+	suite.NoError(iterateParallel(context.Background(), suite.cursor(50, nil), 3, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			current := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				previous := atomic.LoadInt32(&most)
+				if current <= previous || atomic.CompareAndSwapInt32(&most, previous, current) {
+					break
+				}
+			}
+			return nil
+		}))
+	suite.LessOrEqual(most, int32(3))
+}
+
+func (suite *parallelTestSuite) TestKeyOrder() {
+	var lock sync.Mutex
+	byKey := make(map[int][]int)
+	key := func(item *SimpleItem) string { return fmt.Sprint(item.Bravo % 5) }
+	suite.NoError(iterateParallel(context.Background(), suite.cursor(100, nil), 4, 2, key,
+		func(ctx context.Context, item *SimpleItem) error {
+			lock.Lock()
+			defer lock.Unlock()
+			byKey[item.Bravo%5] = append(byKey[item.Bravo%5], item.Bravo)
+			return nil
+		}))
+	suite.Len(byKey, 5)
+	for _, values := range byKey {
+		suite.Len(values, 20)
+		suite.True(sort.IntsAreSorted(values))
+	}
+}
+
+func (suite *parallelTestSuite) TestFunctionError() {
+	failure := errors.New("failure")
+	var count int32
+	err := iterateParallel(context.Background(), suite.cursor(1000, nil), 4, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			atomic.AddInt32(&count, 1)
+			if item.Bravo == 10 {
+				return failure
+			}
+			return nil
+		})
+	suite.ErrorIs(err, failure)
+	suite.Less(atomic.LoadInt32(&count), int32(1000))
+}
+
+func (suite *parallelTestSuite) TestStopIteration() {
+	var count int32
+	suite.NoError(iterateParallel(context.Background(), suite.cursor(1000, nil), 4, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			atomic.AddInt32(&count, 1)
+			if item.Bravo == 10 {
+				return StopIteration
+			}
+			return nil
+		}))
+	suite.Less(atomic.LoadInt32(&count), int32(1000))
+}
+
+func (suite *parallelTestSuite) TestCursorError() {
+	failure := errors.New("cursor failure")
+	err := iterateParallel(context.Background(), suite.cursor(10, failure), 2, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			return nil
+		})
+	suite.ErrorIs(err, failure)
+}
+
+func (suite *parallelTestSuite) TestContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := iterateParallel(ctx, suite.cursor(1000, nil), 2, 1, nil,
+		func(ctx context.Context, item *SimpleItem) error {
+			if item.Bravo == 10 {
+				cancel()
+			}
+			return nil
+		})
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *parallelTestSuite) TestQueueIndex() {
+	suite.Equal(queueIndex("key", 7), queueIndex("key", 7))
+	for i := 0; i < 100; i++ {
+		index := queueIndex(fmt.Sprint(i), 7)
+		suite.GreaterOrEqual(index, 0)
+		suite.Less(index, 7)
+	}
+}
+
+func (suite *parallelTestSuite) cursor(count int, err error) *mongo.Cursor {
+	documents := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		documents[i] = bson.D{
+			{Key: "alpha", Value: fmt.Sprintf("Alpha #%03d", i)},
+			{Key: "bravo", Value: i},
+		}
+	}
+	cursor, cursorErr := mongo.NewCursorFromDocuments(documents, err, nil)
+	suite.Require().NoError(cursorErr)
+	return cursor
+}
@@ -0,0 +1,42 @@
+package mdb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PlanTester provides a utility for verifying that queries use the expected indexes.
+type PlanTester struct {
+	collection *Collection
+}
+
+func NewPlanTester(collection *Collection) *PlanTester {
+	return &PlanTester{collection: collection}
+}
+
+// TestUsesIndex runs explain for the filter and sort and verifies that the winning plan
+// uses the named index without falling back to a collection scan or an in-memory sort.
+// The sort may be nil if the query is not sorted.
+// Returns the query plan for any further checks.
+func (pt *PlanTester) TestUsesIndex(t *testing.T, index string, filter, sort bson.D) *QueryPlan {
+	plan, err := pt.collection.Explain(filter, sort)
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	stages := strings.Join(plan.Stages, " > ")
+	assert.True(t, plan.UsesIndex(index),
+		"index %s not used (%s) for filter %v", index, strings.Join(plan.Indexes, ", "), filter)
+	assert.False(t, plan.HasStage(StageCollectionScan),
+		"collection scan (%s) for filter %v", stages, filter)
+	assert.False(t, plan.HasStage(StageSort),
+		"in-memory sort (%s) for filter %v sort %v", stages, filter, sort)
+	return plan
+}
+
+// TestIndexDescription is TestUsesIndex for the default name of the specified index.
+func (pt *PlanTester) TestIndexDescription(t *testing.T, description *IndexDescription, filter, sort bson.D) *QueryPlan {
+	return pt.TestUsesIndex(t, description.Name(), filter, sort)
+}
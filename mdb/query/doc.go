@@ -0,0 +1,9 @@
+// Package query provides a fluent builder for Mongo filter documents.
+//
+// Use New() to create an unchecked Filter or For[T]() to create a Filter that checks
+// field paths against the bson tags of the struct type T.
+// Conditions are added with methods such as Eq(), Gt(), In(), and Exists()
+// and combined with And(), Or(), and Not().
+// Build() returns the filter as a bson.D for use with mdb collections or the Mongo driver,
+// or an error if the filter referenced any unknown field.
+package query
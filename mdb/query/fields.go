@@ -0,0 +1,161 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrUnknownField is returned when a field path does not exist in the checked type.
+var ErrUnknownField = errors.New("unknown field")
+
+// Fields describes the field paths available in documents of a struct type.
+type Fields struct {
+	typ reflect.Type
+	// open is true if any field name is acceptable at this level (e.g. maps or interfaces).
+	open bool
+	// array is true if this level is an array of the described fields.
+	array  bool
+	fields map[string]*Fields
+}
+
+var (
+	fieldsCache = make(map[reflect.Type]*Fields)
+	fieldsLock  sync.Mutex
+)
+
+// FieldsFor returns the field paths available in documents of type T.
+func FieldsFor[T any]() *Fields {
+	return fieldsForType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func fieldsForType(typ reflect.Type) *Fields {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+	return buildFields(typ, make(map[reflect.Type]*Fields))
+}
+
+var (
+	marshalerType      = reflect.TypeOf((*bson.Marshaler)(nil)).Elem()
+	valueMarshalerType = reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem()
+)
+
+// buildFields walks the type using the same rules as the default bson struct codec.
+// The building map prevents infinite recursion for self-referential types.
+func buildFields(typ reflect.Type, building map[reflect.Type]*Fields) *Fields {
+	if cached, found := fieldsCache[typ]; found {
+		return cached
+	}
+	if inProgress, found := building[typ]; found {
+		return inProgress
+	}
+
+	fields := &Fields{typ: typ}
+	building[typ] = fields
+
+	// Types that marshal themselves have unknown structure.
+	if typ.Implements(marshalerType) || typ.Implements(valueMarshalerType) ||
+		reflect.PtrTo(typ).Implements(marshalerType) || reflect.PtrTo(typ).Implements(valueMarshalerType) {
+		fields.open = true
+		return fields
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return buildFields(typ.Elem(), building)
+	case reflect.Interface, reflect.Map:
+		fields.open = true
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			// Mongo paths pass through arrays to the fields of their elements.
+			elem := buildFields(typ.Elem(), building)
+			fields.array = true
+			fields.open = elem.open
+			fields.fields = elem.fields
+		}
+	case reflect.Struct:
+		fields.fields = make(map[string]*Fields)
+		addStructFields(fields, typ, building)
+	}
+
+	fieldsCache[typ] = fields
+	return fields
+}
+
+func addStructFields(fields *Fields, typ reflect.Type, building map[reflect.Type]*Fields) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue // unexported
+		}
+		name, inline, skip := parseTag(field)
+		if skip {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if inline {
+			if fieldType.Kind() == reflect.Map {
+				fields.open = true
+			} else if fieldType.Kind() == reflect.Struct {
+				addStructFields(fields, fieldType, building)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // unexported embedded type that isn't inlined
+		}
+		fields.fields[name] = buildFields(field.Type, building)
+	}
+}
+
+// parseTag returns the document key for a field and whether it is inlined or skipped.
+func parseTag(field reflect.StructField) (name string, inline, skip bool) {
+	name = strings.ToLower(field.Name)
+	tag, ok := field.Tag.Lookup("bson")
+	if !ok && !strings.Contains(string(field.Tag), ":") && len(field.Tag) > 0 {
+		tag = string(field.Tag)
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, option := range parts {
+		// The driver accepts inline in any position, even as the key.
+		if option == "inline" {
+			inline = true
+		}
+	}
+	return name, inline, false
+}
+
+// Check returns an error wrapping ErrUnknownField if the dotted field path does not exist.
+// Numeric path elements are accepted as array indexes.
+func (f *Fields) Check(path string) error {
+	current := f
+	elements := strings.Split(path, ".")
+	for _, element := range elements {
+		if current.open {
+			return nil
+		}
+		if next, found := current.fields[element]; found {
+			current = next
+			continue
+		}
+		if _, err := strconv.Atoi(element); err == nil && current.array {
+			continue
+		}
+		return fmt.Errorf("%w '%s' in %s", ErrUnknownField, path, f.typ)
+	}
+	return nil
+}
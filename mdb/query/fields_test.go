@@ -0,0 +1,71 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fieldsTestSuite struct {
+	suite.Suite
+}
+
+func TestFieldsSuite(t *testing.T) {
+	suite.Run(t, new(fieldsTestSuite))
+}
+
+type identity struct {
+	ObjectID primitive.ObjectID `bson:"_id,omitempty"`
+}
+
+type element struct {
+	Name  string
+	Count int `bson:"cnt"`
+}
+
+type marshaled struct{}
+
+func (m *marshaled) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(bson.M{})
+}
+
+type document struct {
+	identity `bson:"inline"`
+	Alpha    string `bson:",omitempty"`
+	Bravo    int    `bson:"b"`
+	Skipped  string `bson:"-"`
+	Created  time.Time
+	Nested   *element
+	Elements []element
+	Tags     []string
+	Extra    map[string]interface{}
+	Any      interface{}
+	Custom   *marshaled
+	Self     *document
+	hidden   string
+}
+
+func (suite *fieldsTestSuite) TestCheck() {
+	fields := FieldsFor[document]()
+	for _, path := range []string{
+		"_id", "alpha", "b", "created", "nested", "nested.name", "nested.cnt",
+		"elements", "elements.name", "elements.0.cnt", "tags", "tags.3",
+		"extra", "extra.anything.at.all", "any.thing", "custom.whatever",
+		"self.self.alpha",
+	} {
+		suite.NoError(fields.Check(path), path)
+	}
+	for _, path := range []string{
+		"objectid", "Alpha", "bravo", "skipped", "hidden", "created.year",
+		"nested.count", "elements.cnt.x", "elements.bogus", "nested.0", "goober",
+	} {
+		suite.ErrorIs(fields.Check(path), ErrUnknownField, path)
+	}
+}
+
+func (suite *fieldsTestSuite) TestPointerType() {
+	suite.NoError(FieldsFor[*document]().Check("alpha"))
+}
@@ -0,0 +1,250 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Filter builds a Mongo filter document.
+// Conditions on the same field are combined, all conditions must match.
+type Filter struct {
+	fields     *Fields
+	conditions bson.D
+	extra      bson.A
+	paths      []string
+	err        error
+}
+
+// New returns an empty Filter that does not check field paths.
+func New() *Filter {
+	return &Filter{conditions: bson.D{}}
+}
+
+// For returns an empty Filter that checks field paths against the bson tags of type T.
+func For[T any]() *Filter {
+	return Checked(FieldsFor[T]())
+}
+
+// Checked returns an empty Filter that checks field paths against the specified fields.
+func Checked(fields *Fields) *Filter {
+	return &Filter{fields: fields, conditions: bson.D{}}
+}
+
+// Build returns the filter document or the first error found while building it.
+// Field paths are checked if the filter was created with For() or Checked().
+func (f *Filter) Build() (bson.D, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.fields != nil {
+		for _, path := range f.paths {
+			if err := f.fields.Check(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.document(), nil
+}
+
+func (f *Filter) document() bson.D {
+	document := make(bson.D, 0, len(f.conditions)+1)
+	document = append(document, f.conditions...)
+	if len(f.extra) > 0 {
+		document = append(document, bson.E{Key: "$and", Value: f.extra})
+	}
+	return document
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Eq matches documents where the field equals the value.
+func (f *Filter) Eq(field string, value interface{}) *Filter {
+	return f.add(field, value)
+}
+
+// Ne matches documents where the field does not equal the value.
+func (f *Filter) Ne(field string, value interface{}) *Filter {
+	return f.operator(field, "$ne", value)
+}
+
+// Gt matches documents where the field is greater than the value.
+func (f *Filter) Gt(field string, value interface{}) *Filter {
+	return f.operator(field, "$gt", value)
+}
+
+// Gte matches documents where the field is greater than or equal to the value.
+func (f *Filter) Gte(field string, value interface{}) *Filter {
+	return f.operator(field, "$gte", value)
+}
+
+// Lt matches documents where the field is less than the value.
+func (f *Filter) Lt(field string, value interface{}) *Filter {
+	return f.operator(field, "$lt", value)
+}
+
+// Lte matches documents where the field is less than or equal to the value.
+func (f *Filter) Lte(field string, value interface{}) *Filter {
+	return f.operator(field, "$lte", value)
+}
+
+// In matches documents where the field equals any of the values.
+func (f *Filter) In(field string, values ...interface{}) *Filter {
+	return f.operator(field, "$in", bson.A(values))
+}
+
+// Nin matches documents where the field equals none of the values.
+func (f *Filter) Nin(field string, values ...interface{}) *Filter {
+	return f.operator(field, "$nin", bson.A(values))
+}
+
+// Exists matches documents that do or do not contain the field.
+func (f *Filter) Exists(field string, exists bool) *Filter {
+	return f.operator(field, "$exists", exists)
+}
+
+// Regex matches documents where the field matches the regular expression pattern.
+// The options are Mongo regular expression options such as "i" for case-insensitive matching.
+func (f *Filter) Regex(field, pattern, options string) *Filter {
+	return f.operator(field, "$regex", primitive.Regex{Pattern: pattern, Options: options})
+}
+
+// ElemMatch matches documents where the array field contains an element matching the sub-filter.
+// Field paths in the sub-filter are relative to the array elements.
+func (f *Filter) ElemMatch(field string, sub *Filter) *Filter {
+	f.merge(sub, field+".")
+	return f.operator(field, "$elemMatch", sub.document())
+}
+
+// And matches documents that match all the sub-filters.
+func (f *Filter) And(subs ...*Filter) *Filter {
+	return f.logical("$and", subs)
+}
+
+// Or matches documents that match any of the sub-filters.
+func (f *Filter) Or(subs ...*Filter) *Filter {
+	return f.logical("$or", subs)
+}
+
+// Nor matches documents that match none of the sub-filters.
+func (f *Filter) Nor(subs ...*Filter) *Filter {
+	return f.logical("$nor", subs)
+}
+
+// Not matches documents that do not match the sub-filter.
+// A sub-filter with a single field condition is negated with $not on that field,
+// any other sub-filter is negated with $nor.
+func (f *Filter) Not(sub *Filter) *Filter {
+	f.merge(sub, "")
+	document := sub.document()
+	if len(document) == 1 && !strings.HasPrefix(document[0].Key, "$") {
+		field := document[0].Key
+		var negated interface{}
+		switch value := document[0].Value.(type) {
+		case bson.D:
+			if isOperatorDocument(value) {
+				negated = value
+			} else {
+				negated = bson.D{{Key: "$eq", Value: value}}
+			}
+		case primitive.Regex:
+			negated = value
+		default:
+			negated = bson.D{{Key: "$eq", Value: value}}
+		}
+		return f.set(field, bson.D{{Key: "$not", Value: negated}})
+	}
+	return f.logical("$nor", []*Filter{sub})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// add a condition for a field, combining operator conditions on the same field.
+func (f *Filter) add(field string, value interface{}) *Filter {
+	if field == "" {
+		f.setError(fmt.Errorf("empty field name"))
+		return f
+	}
+	f.paths = append(f.paths, field)
+	return f.set(field, value)
+}
+
+// set the condition for a field without recording the field path.
+func (f *Filter) set(field string, value interface{}) *Filter {
+	for i, elem := range f.conditions {
+		if elem.Key != field {
+			continue
+		}
+		existing, existingOK := elem.Value.(bson.D)
+		adding, addingOK := value.(bson.D)
+		if existingOK && addingOK && isOperatorDocument(existing) && isOperatorDocument(adding) {
+			f.conditions[i].Value = append(existing, adding...)
+		} else {
+			// Duplicate keys are not allowed so keep the additional condition separately.
+			f.extra = append(f.extra, bson.D{{Key: field, Value: value}})
+		}
+		return f
+	}
+	f.conditions = append(f.conditions, bson.E{Key: field, Value: value})
+	return f
+}
+
+func (f *Filter) operator(field, operator string, value interface{}) *Filter {
+	return f.add(field, bson.D{{Key: operator, Value: value}})
+}
+
+func (f *Filter) logical(operator string, subs []*Filter) *Filter {
+	documents := make(bson.A, 0, len(subs))
+	for _, sub := range subs {
+		f.merge(sub, "")
+		documents = append(documents, sub.document())
+	}
+	if operator == "$and" {
+		f.extra = append(f.extra, documents...)
+	} else if f.hasCondition(operator) {
+		f.extra = append(f.extra, bson.D{{Key: operator, Value: documents}})
+	} else {
+		f.conditions = append(f.conditions, bson.E{Key: operator, Value: documents})
+	}
+	return f
+}
+
+func (f *Filter) hasCondition(key string) bool {
+	for _, elem := range f.conditions {
+		if elem.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// merge the field paths and any error from a sub-filter.
+func (f *Filter) merge(sub *Filter, prefix string) {
+	if sub.err != nil {
+		f.setError(sub.err)
+	}
+	for _, path := range sub.paths {
+		f.paths = append(f.paths, prefix+path)
+	}
+}
+
+func (f *Filter) setError(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// isOperatorDocument returns true if all keys in the document are operators.
+func isOperatorDocument(document bson.D) bool {
+	if len(document) < 1 {
+		return false
+	}
+	for _, elem := range document {
+		if !strings.HasPrefix(elem.Key, "$") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type filterTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterSuite(t *testing.T) {
+	suite.Run(t, new(filterTestSuite))
+}
+
+func (suite *filterTestSuite) TestEmpty() {
+	suite.Equal(bson.D{}, suite.build(New()))
+}
+
+func (suite *filterTestSuite) TestOperators() {
+	suite.Equal(bson.D{
+		{Key: "alpha", Value: "one"},
+		{Key: "b", Value: bson.D{{Key: "$gt", Value: 1}, {Key: "$lte", Value: 5}}},
+		{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"x", "y"}}}},
+		{Key: "nested", Value: bson.D{{Key: "$exists", Value: true}}},
+		{Key: "nested.name", Value: bson.D{{Key: "$regex", Value: primitive.Regex{Pattern: "^a", Options: "i"}}}},
+		{Key: "created", Value: bson.D{{Key: "$ne", Value: nil}}},
+	}, suite.build(For[document]().
+		Eq("alpha", "one").
+		Gt("b", 1).
+		Lte("b", 5).
+		In("tags", "x", "y").
+		Exists("nested", true).
+		Regex("nested.name", "^a", "i").
+		Ne("created", nil)))
+}
+
+func (suite *filterTestSuite) TestDuplicateField() {
+	suite.Equal(bson.D{
+		{Key: "alpha", Value: "one"},
+		{Key: "$and", Value: bson.A{bson.D{{Key: "alpha", Value: bson.D{{Key: "$ne", Value: "two"}}}}}},
+	}, suite.build(New().Eq("alpha", "one").Ne("alpha", "two")))
+}
+
+func (suite *filterTestSuite) TestLogical() {
+	suite.Equal(bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "alpha", Value: "one"}},
+			bson.D{{Key: "b", Value: bson.D{{Key: "$lt", Value: 0}}}},
+		}},
+		{Key: "$and", Value: bson.A{
+			bson.D{{Key: "tags", Value: "x"}},
+			bson.D{{Key: "tags", Value: "y"}},
+		}},
+	}, suite.build(For[document]().
+		Or(New().Eq("alpha", "one"), New().Lt("b", 0)).
+		And(New().Eq("tags", "x"), New().Eq("tags", "y"))))
+}
+
+func (suite *filterTestSuite) TestNot() {
+	suite.Equal(bson.D{
+		{Key: "b", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: 5}}}}},
+		{Key: "alpha", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$eq", Value: "one"}}}}},
+		{Key: "$nor", Value: bson.A{bson.D{
+			{Key: "alpha", Value: "two"},
+			{Key: "b", Value: 2},
+		}}},
+	}, suite.build(For[document]().
+		Not(New().Gt("b", 5)).
+		Not(New().Eq("alpha", "one")).
+		Not(New().Eq("alpha", "two").Eq("b", 2))))
+}
+
+func (suite *filterTestSuite) TestElemMatch() {
+	suite.Equal(bson.D{
+		{Key: "elements", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+			{Key: "name", Value: "x"},
+			{Key: "cnt", Value: bson.D{{Key: "$gte", Value: 2}}},
+		}}}},
+	}, suite.build(For[document]().
+		ElemMatch("elements", New().Eq("name", "x").Gte("cnt", 2))))
+}
+
+func (suite *filterTestSuite) TestUnknownField() {
+	_, err := For[document]().Eq("alpha", "one").Gt("bravo", 1).Build()
+	suite.ErrorIs(err, ErrUnknownField)
+	_, err = For[document]().Or(New().Eq("goober", 1)).Build()
+	suite.ErrorIs(err, ErrUnknownField)
+	_, err = For[document]().ElemMatch("elements", New().Eq("count", 1)).Build()
+	suite.ErrorIs(err, ErrUnknownField)
+	_, err = For[document]().Not(New().Eq("hidden", 1)).Build()
+	suite.ErrorIs(err, ErrUnknownField)
+	// Unchecked filters accept anything.
+	_, err = New().Eq("goober", 1).Build()
+	suite.NoError(err)
+}
+
+func (suite *filterTestSuite) TestEmptyField() {
+	_, err := New().Eq("", 1).Build()
+	suite.Error(err)
+}
+
+func (suite *filterTestSuite) build(filter *Filter) bson.D {
+	document, err := filter.Build()
+	suite.Require().NoError(err)
+	return document
+}
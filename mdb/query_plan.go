@@ -0,0 +1,134 @@
+package mdb
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// StageCollectionScan is the query plan stage for a full collection scan.
+	StageCollectionScan = "COLLSCAN"
+
+	// StageIndexScan is the query plan stage for an index scan.
+	StageIndexScan = "IXSCAN"
+
+	// StageIDHack is the query plan stage for an optimized lookup by _id.
+	StageIDHack = "IDHACK"
+
+	// StageSort is the query plan stage for an in-memory sort.
+	StageSort = "SORT"
+
+	// idIndexName is the name of the index Mongo creates on _id for every collection.
+	idIndexName = "_id_"
+)
+
+// QueryPlan summarizes the winning plan returned by explain for a query.
+type QueryPlan struct {
+	// Stages in the winning plan, from the top of the plan tree down.
+	Stages []string
+
+	// Indexes used by the winning plan, in the order they were found.
+	Indexes []string
+}
+
+// HasStage returns true if the winning plan contains the specified stage.
+func (qp *QueryPlan) HasStage(stage string) bool {
+	for _, s := range qp.Stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesIndex returns true if the winning plan uses the named index.
+func (qp *QueryPlan) UsesIndex(name string) bool {
+	for _, index := range qp.Indexes {
+		if index == name {
+			return true
+		}
+	}
+	return false
+}
+
+// planStage is used to decode the tree of stages in a winning plan.
+type planStage struct {
+	Stage       string       `bson:"stage"`
+	IndexName   string       `bson:"indexName"`
+	InputStage  *planStage   `bson:"inputStage"`
+	InputStages []*planStage `bson:"inputStages"`
+	// Newer servers may wrap the plan in a queryPlan field.
+	QueryPlan *planStage `bson:"queryPlan"`
+}
+
+func (ps *planStage) collect(plan *QueryPlan) {
+	if ps == nil {
+		return
+	}
+	if ps.Stage != "" {
+		plan.Stages = append(plan.Stages, ps.Stage)
+	}
+	if ps.IndexName != "" {
+		plan.Indexes = append(plan.Indexes, ps.IndexName)
+	} else if ps.Stage == StageIDHack {
+		plan.Indexes = append(plan.Indexes, idIndexName)
+	}
+	ps.QueryPlan.collect(plan)
+	ps.InputStage.collect(plan)
+	for _, input := range ps.InputStages {
+		input.collect(plan)
+	}
+}
+
+type explainResult struct {
+	QueryPlanner struct {
+		WinningPlan *planStage `bson:"winningPlan"`
+	} `bson:"queryPlanner"`
+}
+
+// parseQueryPlan converts the raw result of an explain command into a QueryPlan.
+func parseQueryPlan(raw bson.Raw) (*QueryPlan, error) {
+	var result explainResult
+	if err := bson.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal explain result: %w", err)
+	}
+	if result.QueryPlanner.WinningPlan == nil {
+		return nil, fmt.Errorf("no winning plan in explain result")
+	}
+	plan := &QueryPlan{}
+	result.QueryPlanner.WinningPlan.collect(plan)
+	return plan, nil
+}
+
+// Explain returns the winning query plan for the specified filter and sort.
+// The sort may be nil if the query is not sorted.
+func (c *Collection) Explain(filter, sort bson.D) (*QueryPlan, error) {
+	if filter == nil {
+		filter = NoFilter()
+	}
+	find := bson.D{
+		{Key: "find", Value: c.Name()},
+		{Key: "filter", Value: filter},
+	}
+	if len(sort) > 0 {
+		find = append(find, bson.E{Key: "sort", Value: sort})
+	}
+
+	ctx, cancel := c.ContextWithTimeout()
+	defer cancel()
+	raw, err := c.Collection.Database().RunCommand(ctx, bson.D{
+		{Key: "explain", Value: find},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}).DecodeBytes()
+	if err != nil {
+		return nil, c.opError("explain", filter, err)
+	}
+
+	plan, err := parseQueryPlan(raw)
+	if err != nil {
+		return nil, c.opError("explain", filter, err)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,57 @@
+//go:build database
+
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryPlanDbTestSuite struct {
+	AccessTestSuite
+	collection *Collection
+	index      *IndexDescription
+}
+
+func TestQueryPlanDbSuite(t *testing.T) {
+	suite.Run(t, new(queryPlanDbTestSuite))
+}
+
+func (suite *queryPlanDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.index = NewIndexDescription(true, "alpha", "bravo")
+	suite.collection = suite.ConnectCollection(testCollection, suite.index)
+	suite.Require().NoError(suite.collection.Create(SimpleItem1))
+	suite.Require().NoError(suite.collection.Create(SimpleItem2))
+	suite.Require().NoError(suite.collection.Create(SimpleItem3))
+}
+
+func (suite *queryPlanDbTestSuite) TestExplainIndexed() {
+	plan, err := suite.collection.Explain(SimpleItem1.Filter(), nil)
+	suite.Require().NoError(err)
+	suite.True(plan.UsesIndex(suite.index.Name()))
+	suite.False(plan.HasStage(StageCollectionScan))
+}
+
+func (suite *queryPlanDbTestSuite) TestExplainCollectionScan() {
+	plan, err := suite.collection.Explain(bson.D{{Key: "charlie", Value: SimpleCharlie1}}, nil)
+	suite.Require().NoError(err)
+	suite.True(plan.HasStage(StageCollectionScan))
+	suite.Empty(plan.Indexes)
+}
+
+func (suite *queryPlanDbTestSuite) TestExplainInMemorySort() {
+	plan, err := suite.collection.Explain(
+		bson.D{{Key: "alpha", Value: "one"}}, bson.D{{Key: "delta", Value: 1}})
+	suite.Require().NoError(err)
+	suite.True(plan.HasStage(StageSort))
+}
+
+func (suite *queryPlanDbTestSuite) TestPlanTester() {
+	tester := NewPlanTester(suite.collection)
+	tester.TestIndexDescription(suite.T(), suite.index,
+		bson.D{{Key: "alpha", Value: "two"}}, bson.D{{Key: "alpha", Value: 1}, {Key: "bravo", Value: 1}})
+	tester.TestUsesIndex(suite.T(), "_id_", SimpleItem1.IDfilter(), nil)
+}
@@ -0,0 +1,96 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryPlanTestSuite struct {
+	suite.Suite
+}
+
+func TestQueryPlanSuite(t *testing.T) {
+	suite.Run(t, new(queryPlanTestSuite))
+}
+
+func (suite *queryPlanTestSuite) TestIndexScan() {
+	plan := suite.parse(bson.M{
+		"queryPlanner": bson.M{
+			"winningPlan": bson.M{
+				"stage": "FETCH",
+				"inputStage": bson.M{
+					"stage":     "IXSCAN",
+					"indexName": "alpha_1",
+				},
+			},
+		},
+	})
+	suite.Equal([]string{"FETCH", StageIndexScan}, plan.Stages)
+	suite.Equal([]string{"alpha_1"}, plan.Indexes)
+	suite.True(plan.UsesIndex("alpha_1"))
+	suite.False(plan.UsesIndex("bravo_1"))
+	suite.False(plan.HasStage(StageCollectionScan))
+	suite.False(plan.HasStage(StageSort))
+}
+
+func (suite *queryPlanTestSuite) TestCollectionScanSort() {
+	plan := suite.parse(bson.M{
+		"queryPlanner": bson.M{
+			"winningPlan": bson.M{
+				"stage": "SORT",
+				"inputStage": bson.M{
+					"stage": "COLLSCAN",
+				},
+			},
+		},
+	})
+	suite.True(plan.HasStage(StageSort))
+	suite.True(plan.HasStage(StageCollectionScan))
+	suite.Empty(plan.Indexes)
+}
+
+func (suite *queryPlanTestSuite) TestNestedQueryPlan() {
+	plan := suite.parse(bson.M{
+		"queryPlanner": bson.M{
+			"winningPlan": bson.M{
+				"queryPlan": bson.M{
+					"stage": "OR",
+					"inputStages": bson.A{
+						bson.M{"stage": "IXSCAN", "indexName": "alpha_1"},
+						bson.M{"stage": "IXSCAN", "indexName": "bravo_1"},
+					},
+				},
+			},
+		},
+	})
+	suite.Equal([]string{"OR", StageIndexScan, StageIndexScan}, plan.Stages)
+	suite.True(plan.UsesIndex("alpha_1"))
+	suite.True(plan.UsesIndex("bravo_1"))
+}
+
+func (suite *queryPlanTestSuite) TestIDHack() {
+	plan := suite.parse(bson.M{
+		"queryPlanner": bson.M{
+			"winningPlan": bson.M{"stage": "IDHACK"},
+		},
+	})
+	suite.True(plan.UsesIndex("_id_"))
+}
+
+func (suite *queryPlanTestSuite) TestNoWinningPlan() {
+	raw, err := bson.Marshal(bson.M{"ok": 1})
+	suite.Require().NoError(err)
+	_, err = parseQueryPlan(raw)
+	suite.Error(err)
+}
+
+func (suite *queryPlanTestSuite) parse(explained bson.M) *QueryPlan {
+	raw, err := bson.Marshal(explained)
+	suite.Require().NoError(err)
+	plan, err := parseQueryPlan(raw)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(plan)
+	return plan
+}
@@ -0,0 +1,119 @@
+package mdb
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReplaceStatus describes the outcome of ReplaceDocument or Upsert.
+// ItemUnchanged is never the outcome for Timestamped items since the update time always changes.
+type ReplaceStatus int
+
+const (
+	ItemUnchanged ReplaceStatus = iota
+	ItemReplaced
+	ItemCreated
+)
+
+func (rs ReplaceStatus) String() string {
+	switch rs {
+	case ItemUnchanged:
+		return "unchanged"
+	case ItemReplaced:
+		return "replaced"
+	case ItemCreated:
+		return "created"
+	default:
+		return fmt.Sprintf("ReplaceStatus(%d)", int(rs))
+	}
+}
+
+// ReplaceResult is returned by ReplaceDocument and Upsert.
+type ReplaceResult struct {
+	Status ReplaceStatus
+
+	// UpsertedID is the _id of a created item, nil otherwise.
+	UpsertedID interface{}
+}
+
+// ReplaceDocument replaces the entire document referenced by filter with the specified item.
+// Unlike Replace, fields not present in the item are removed from the document.
+// If upsert is true the item is created if the filter matches no document,
+// otherwise ErrNoItemMatch is returned.
+// If the filter matches more than one document mongo-go-driver will choose one to replace.
+func (c *Collection) ReplaceDocument(filter, item interface{}, upsert bool) (*ReplaceResult, error) {
+	result, err := c.ReplaceOne(c.ctx, c.activeFilter(filter), item, options.Replace().SetUpsert(upsert))
+	if err != nil {
+		return nil, c.opError("replace", filter, err)
+	}
+	return c.replaceResult(filter, result)
+}
+
+func (c *Collection) replaceResult(filter interface{}, result *mongo.UpdateResult) (*ReplaceResult, error) {
+	switch {
+	case result.UpsertedCount > 0:
+		return &ReplaceResult{Status: ItemCreated, UpsertedID: result.UpsertedID}, nil
+	case result.MatchedCount < 1:
+		return nil, c.opError("replace", filter, ErrNoItemMatch)
+	case result.ModifiedCount < 1:
+		return &ReplaceResult{Status: ItemUnchanged}, nil
+	default:
+		return &ReplaceResult{Status: ItemReplaced}, nil
+	}
+}
+
+// ReplaceDocument replaces the entire document referenced by filter with the specified item.
+// Unlike Replace, fields not present in the item are removed from the document.
+// If upsert is true the item is created if the filter matches no document,
+// otherwise ErrNoItemMatch is returned.
+// Versioned items are handled as for Replace.
+// Timestamped items have their timestamps set as for Create since replacement documents can't use server time,
+// so the creation time should be kept from the item as read from the database,
+// and since the update time is always changed the result is never ItemUnchanged.
+// If the filter matches more than one document mongo-go-driver will choose one to replace.
+func (c *TypedCollection[T]) ReplaceDocument(filter, item interface{}, upsert bool) (*ReplaceResult, error) {
+	if err := beforeUpdate(c.ctx, item); err != nil {
+		return nil, c.opError("replace", filter, err)
+	}
+	if stamped, ok := item.(Timestamped); ok {
+		created, updated := stamped.CreatedTime(), stamped.UpdatedTime()
+		touchCreated(stamped)
+		result, err := c.replaceVersioned(filter, item, upsert)
+		if err != nil {
+			stamped.SetCreatedTime(created)
+			stamped.SetUpdatedTime(updated)
+		}
+		return result, err
+	}
+
+	return c.replaceVersioned(filter, item, upsert)
+}
+
+// Upsert replaces the entire document referenced by filter with the specified item
+// or creates it if the filter matches no document.
+// See ReplaceDocument for details.
+func (c *TypedCollection[T]) Upsert(filter bson.D, item *T) (*ReplaceResult, error) {
+	return c.ReplaceDocument(filter, item, true)
+}
+
+// replaceVersioned replaces the document matching the version of a Versioned item.
+func (c *TypedCollection[T]) replaceVersioned(filter, item interface{}, upsert bool) (*ReplaceResult, error) {
+	versioned, ok := item.(Versioned)
+	if !ok {
+		return c.Collection.ReplaceDocument(filter, item, upsert)
+	}
+
+	version := versioned.CurrentVersion()
+	versioned.SetVersion(version + 1)
+	result, err := c.Collection.ReplaceDocument(
+		addCondition(filter, versionCondition(version)), item, upsert && version == 0)
+	if err != nil {
+		versioned.SetVersion(version)
+		return nil, c.versionError("replace", filter, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type replaceTestSuite struct {
+	suite.Suite
+}
+
+func TestReplaceSuite(t *testing.T) {
+	suite.Run(t, new(replaceTestSuite))
+}
+
+func (suite *replaceTestSuite) TestStatusString() {
+	suite.Equal("unchanged", ItemUnchanged.String())
+	suite.Equal("replaced", ItemReplaced.String())
+	suite.Equal("created", ItemCreated.String())
+	suite.Equal("ReplaceStatus(7)", ReplaceStatus(7).String())
+}
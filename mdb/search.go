@@ -0,0 +1,108 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultScoreField is the field into which Search projects the text score if no field is specified.
+const DefaultScoreField = "_textScore"
+
+// SearchOptions configures Search.
+type SearchOptions struct {
+	// Language for stop words, stemming, and tokenizing, default is the language of the text index.
+	// Use "none" for simple tokenizing without stop words or stemming.
+	Language string
+
+	// CaseSensitive matches case exactly.
+	CaseSensitive bool
+
+	// DiacriticSensitive matches diacritical marks exactly.
+	DiacriticSensitive bool
+
+	// ScoreField is the field into which the text score is projected, default DefaultScoreField.
+	// Use the name of a float64 field in the collection type to have the score decoded into items.
+	// The score field is not written to the database.
+	ScoreField string
+
+	// Find options for the cursor such as limit, skip, or projection.
+	// The text score sort replaces any sort.
+	Find *options.FindOptions
+}
+
+// SearchResult is an item matched by Search with its text score.
+type SearchResult[T any] struct {
+	Item  *T
+	Score float64
+}
+
+// Search finds items matching the text search and the filter ordered by descending text score.
+// The collection must have a text index, see NewTextIndexDescription.
+// The text is a space separated list of terms, a term in escaped double quotes matches a phrase
+// and a term prefixed with '-' excludes items containing it.
+func (c *TypedCollection[T]) Search(
+	ctx context.Context, text string, filter bson.D, opts ...*SearchOptions) ([]SearchResult[T], error) {
+	opt := &SearchOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	scoreField := opt.ScoreField
+	if scoreField == "" {
+		scoreField = DefaultScoreField
+	}
+
+	findOpts := options.Find()
+	if opt.Find != nil {
+		findOpts = options.MergeFindOptions(opt.Find)
+	}
+	textScore := bson.D{{Key: "$meta", Value: "textScore"}}
+	projection := interface{}(bson.D{{Key: scoreField, Value: textScore}})
+	if findOpts.Projection != nil {
+		var err error
+		if projection, err = addToDocument(findOpts.Projection, bson.E{Key: scoreField, Value: textScore}); err != nil {
+			return nil, c.opError("search", filter, fmt.Errorf("projection: %w", err))
+		}
+	}
+	findOpts.SetProjection(projection)
+	findOpts.SetSort(bson.D{{Key: scoreField, Value: textScore}})
+
+	cursor, err := c.Collection.Collection.Find(ctx, c.activeFilter(textFilter(filter, text, opt)), findOpts)
+	if err != nil {
+		return nil, c.opError("search", filter, err)
+	}
+	results := make([]SearchResult[T], 0)
+	err = iterateCursor(ctx, cursor, func(cursor *mongo.Cursor) error {
+		item, err := decodeItem[T](ctx, cursor.Decode)
+		if err != nil {
+			return err
+		}
+		result := SearchResult[T]{Item: item}
+		result.Score, _ = cursor.Current.Lookup(scoreField).DoubleOK()
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, c.opError("search", filter, err)
+	}
+
+	return results, nil
+}
+
+// textFilter returns the filter with the $text condition added.
+func textFilter(filter bson.D, text string, opt *SearchOptions) interface{} {
+	search := bson.D{{Key: "$search", Value: text}}
+	if opt.Language != "" {
+		search = append(search, bson.E{Key: "$language", Value: opt.Language})
+	}
+	if opt.CaseSensitive {
+		search = append(search, bson.E{Key: "$caseSensitive", Value: true})
+	}
+	if opt.DiacriticSensitive {
+		search = append(search, bson.E{Key: "$diacriticSensitive", Value: true})
+	}
+	return addCondition(filter, bson.E{Key: "$text", Value: search})
+}
@@ -0,0 +1,113 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type searchDbTestSuite struct {
+	AccessTestSuite
+	typed  *TypedCollection[SimpleItem]
+	scored *TypedCollection[scoredItem]
+}
+
+type scoredItem struct {
+	Alpha   string  `bson:"alpha"`
+	Charlie string  `bson:"charlie"`
+	Score   float64 `bson:"score,omitempty"`
+}
+
+func TestSearchDbSuite(t *testing.T) {
+	suite.Run(t, new(searchDbTestSuite))
+}
+
+func (suite *searchDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollectionSearch,
+		NewTextIndexDescription(bson.D{{Key: "alpha", Value: 10}, {Key: "charlie", Value: 1}}))
+	var err error
+	suite.scored, err = ConnectTypedCollection[scoredItem](suite.access, testCollectionSearch)
+	suite.Require().NoError(err)
+}
+
+func (suite *searchDbTestSuite) SetupTest() {
+	suite.Require().NoError(suite.typed.Create(&SimpleItem{Alpha: "tango", Charlie: "Dance the night away"}))
+	suite.Require().NoError(suite.typed.Create(&SimpleItem{Alpha: "waltz", Charlie: "Tango is not a waltz"}))
+	suite.Require().NoError(suite.typed.Create(&SimpleItem{Alpha: "polka", Charlie: "Café music", Bravo: 2}))
+}
+
+func (suite *searchDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *searchDbTestSuite) TestIndex() {
+	NewIndexTester().TestIndexes(suite.T(), &suite.typed.Collection,
+		NewTextIndexDescription(bson.D{{Key: "alpha", Value: 10}, {Key: "charlie", Value: 1}}))
+}
+
+func (suite *searchDbTestSuite) TestSearch() {
+	results, err := suite.typed.Search(context.Background(), "tango", nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 2)
+	// Higher weight on alpha field sorts the match in alpha first:
+	suite.Equal("tango", results[0].Item.Alpha)
+	suite.Equal("waltz", results[1].Item.Alpha)
+	suite.Greater(results[0].Score, results[1].Score)
+	suite.Greater(results[1].Score, 0.0)
+}
+
+func (suite *searchDbTestSuite) TestSearchFilter() {
+	results, err := suite.typed.Search(context.Background(), "tango",
+		bson.D{{Key: "alpha", Value: "waltz"}})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.Equal("waltz", results[0].Item.Alpha)
+	results, err = suite.typed.Search(context.Background(), "mambo", nil)
+	suite.Require().NoError(err)
+	suite.Empty(results)
+}
+
+func (suite *searchDbTestSuite) TestSearchOptions() {
+	results, err := suite.typed.Search(context.Background(), "Tango", nil,
+		&SearchOptions{CaseSensitive: true})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.Equal("waltz", results[0].Item.Alpha)
+	results, err = suite.typed.Search(context.Background(), "cafe", nil,
+		&SearchOptions{DiacriticSensitive: true})
+	suite.Require().NoError(err)
+	suite.Empty(results)
+	results, err = suite.typed.Search(context.Background(), "cafe", nil)
+	suite.Require().NoError(err)
+	suite.Len(results, 1)
+	results, err = suite.typed.Search(context.Background(), "the", nil,
+		&SearchOptions{Language: "none"})
+	suite.Require().NoError(err)
+	suite.Len(results, 1, "stop word found without language")
+	results, err = suite.typed.Search(context.Background(), "tango", nil,
+		&SearchOptions{Find: options.Find().SetLimit(1)})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 1)
+	suite.Equal("tango", results[0].Item.Alpha)
+}
+
+func (suite *searchDbTestSuite) TestScoreField() {
+	results, err := suite.scored.Search(context.Background(), "tango", nil,
+		&SearchOptions{ScoreField: "score"})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 2)
+	suite.Greater(results[0].Item.Score, 0.0)
+	suite.Equal(results[0].Score, results[0].Item.Score)
+	results, err = suite.scored.Search(context.Background(), "tango", nil,
+		&SearchOptions{ScoreField: "score", Find: options.Find().SetProjection(bson.D{{Key: "alpha", Value: 1}})})
+	suite.Require().NoError(err)
+	suite.Require().Len(results, 2)
+	suite.Empty(results[0].Item.Charlie)
+	suite.Greater(results[0].Item.Score, 0.0)
+}
@@ -0,0 +1,35 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type searchTestSuite struct {
+	suite.Suite
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(searchTestSuite))
+}
+
+func (suite *searchTestSuite) TestTextFilter() {
+	suite.Equal(bson.D{
+		{Key: "$text", Value: bson.D{{Key: "$search", Value: "tango"}}},
+	}, textFilter(nil, "tango", &SearchOptions{}))
+	suite.Equal(bson.D{
+		{Key: "bravo", Value: 2},
+		{Key: "$text", Value: bson.D{
+			{Key: "$search", Value: "tango"},
+			{Key: "$language", Value: "none"},
+			{Key: "$caseSensitive", Value: true},
+			{Key: "$diacriticSensitive", Value: true},
+		}},
+	}, textFilter(bson.D{{Key: "bravo", Value: 2}}, "tango", &SearchOptions{
+		Language:           "none",
+		CaseSensitive:      true,
+		DiacriticSensitive: true,
+	}))
+}
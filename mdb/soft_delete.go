@@ -0,0 +1,138 @@
+package mdb
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultSoftDeleteField is the default name of the field holding the time an item was soft deleted.
+const DefaultSoftDeleteField = "deletedAt"
+
+// ErrNoSoftDelete is returned by soft delete operations on a collection without soft delete configured.
+var ErrNoSoftDelete = errors.New("soft delete not configured")
+
+// SoftDeleted returns true if soft delete is configured for the collection.
+// When configured Delete and DeleteAll set a timestamp field instead of removing items
+// and filters for other operations are restricted to items without the field.
+// Item types should not include the field or should mark it omitempty
+// so that Create and Replace don't set it.
+// FindOrCreate creates a new item if the matching item has been soft deleted,
+// if a unique index covers the filter that fails with an error for which IsDuplicate is true
+// and the deleted item should be restored with Restore instead.
+func (c *Collection) SoftDeleted() bool {
+	return c.softDelete != ""
+}
+
+// Restore an item that was soft deleted.
+// Set idempotent to true to avoid errors if there is no matching deleted item.
+func (c *Collection) Restore(filter bson.D, idempotent bool) error {
+	if !c.SoftDeleted() {
+		return c.opError("restore", filter, ErrNoSoftDelete)
+	}
+	result, err := c.UpdateOne(c.ctx, c.deletedFilter(filter), bson.D{
+		{Key: "$unset", Value: bson.D{{Key: c.softDelete, Value: ""}}},
+	})
+	if err != nil {
+		return c.opError("restore", filter, err)
+	}
+	if result.MatchedCount == 0 && !idempotent {
+		return c.opError("restore", filter, ErrNoItemMatch)
+	}
+
+	return nil
+}
+
+// FindDeleted finds an item that was soft deleted and returns it as a blank interface.
+// The result will likely contain bson objects.
+// Options may be used to specify sort, skip, projection, hint, collation, or maxTime.
+func (c *Collection) FindDeleted(filter bson.D, opts ...*options.FindOneOptions) (interface{}, error) {
+	if !c.SoftDeleted() {
+		return nil, c.opError("find deleted", filter, ErrNoSoftDelete)
+	}
+	var item interface{}
+	if err := c.FindOne(c.ctx, c.deletedFilter(filter), opts...).Decode(&item); err != nil {
+		return nil, c.opError("find deleted", filter, err)
+	}
+
+	return item, nil
+}
+
+// Purge permanently removes items that were soft deleted more than the specified duration ago.
+// The deletion time is set by the server so the cutoff is computed with the server clock.
+// Returns the number of items removed.
+func (c *Collection) Purge(olderThan time.Duration) (int64, error) {
+	if !c.SoftDeleted() {
+		return 0, c.opError("purge", nil, ErrNoSoftDelete)
+	}
+	filter := c.deletedFilter(bson.D{{Key: "$expr", Value: bson.D{{Key: "$lt", Value: bson.A{
+		"$" + c.softDelete,
+		bson.D{{Key: "$subtract", Value: bson.A{"$$NOW", olderThan.Milliseconds()}}},
+	}}}}})
+	result, err := c.DeleteMany(c.ctx, filter)
+	if err != nil {
+		return 0, c.opError("purge", filter, err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// FindDeleted finds an item that was soft deleted.
+// Options may be used to specify sort, skip, projection, hint, collation, or maxTime.
+func (c *TypedCollection[T]) FindDeleted(filter bson.D, opts ...*options.FindOneOptions) (*T, error) {
+	if !c.SoftDeleted() {
+		return nil, c.opError("find deleted", filter, ErrNoSoftDelete)
+	}
+	item, err := decodeItem[T](c.ctx, c.FindOne(c.ctx, c.deletedFilter(filter), opts...).Decode)
+	if err != nil {
+		return nil, c.opError("find deleted", filter, err)
+	}
+
+	return item, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// softDeleteChanges returns the update that marks items as deleted.
+func (c *Collection) softDeleteChanges() bson.D {
+	return bson.D{{Key: "$currentDate", Value: bson.D{{Key: c.softDelete, Value: true}}}}
+}
+
+// activeFilter restricts the filter to items that are not soft deleted.
+// The filter is returned unchanged if soft delete is not configured.
+func (c *Collection) activeFilter(filter interface{}) interface{} {
+	if !c.SoftDeleted() {
+		return filter
+	}
+	// Matches null as well as missing.
+	return addCondition(filter, bson.E{Key: c.softDelete, Value: nil})
+}
+
+// deletedFilter restricts the filter to items that are soft deleted.
+func (c *Collection) deletedFilter(filter interface{}) interface{} {
+	return addCondition(filter, bson.E{Key: c.softDelete, Value: bson.D{{Key: "$type", Value: "date"}}})
+}
+
+// addCondition returns a new filter with the condition added.
+func addCondition(filter interface{}, condition bson.E) interface{} {
+	if filter == nil {
+		return bson.D{condition}
+	}
+	if document, ok := filter.(bson.D); ok {
+		found := false
+		for _, elem := range document {
+			if elem.Key == condition.Key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result := make(bson.D, 0, len(document)+1)
+			result = append(result, document...)
+			return append(result, condition)
+		}
+	}
+	return bson.D{{Key: "$and", Value: bson.A{filter, bson.D{condition}}}}
+}
@@ -0,0 +1,139 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type softDeleteDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[SimpleItem]
+}
+
+func TestSoftDeleteDbSuite(t *testing.T) {
+	suite.Run(t, new(softDeleteDbTestSuite))
+}
+
+func (suite *softDeleteDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollectionSoftDelete)
+}
+
+func (suite *softDeleteDbTestSuite) SetupTest() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+}
+
+func (suite *softDeleteDbTestSuite) TearDownTest() {
+	_, err := suite.typed.Collection.Collection.DeleteMany(context.Background(), NoFilter())
+	suite.NoError(err)
+}
+
+func (suite *softDeleteDbTestSuite) TestDelete() {
+	suite.Require().True(suite.typed.SoftDeleted())
+	suite.Require().NoError(suite.typed.Delete(SimpleItem2.Filter(), false))
+	suite.ErrorIs(suite.typed.Delete(SimpleItem2.Filter(), false), ErrNoItemMatch)
+	suite.NoError(suite.typed.Delete(SimpleItem2.Filter(), true))
+	_, err := suite.typed.Find(SimpleItem2.Filter())
+	suite.True(IsNotFound(err))
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+	exists, err := suite.typed.Exists(context.Background(), SimpleItem2.Filter())
+	suite.Require().NoError(err)
+	suite.False(exists)
+	var alpha []string
+	suite.NoError(suite.typed.Iterate(NoFilter(), func(item *SimpleItem) error {
+		alpha = append(alpha, item.Alpha)
+		return nil
+	}, options.Find().SetSort(bson.D{{Key: "bravo", Value: 1}})))
+	suite.Equal([]string{"one", "three"}, alpha)
+	// Still present in the database:
+	raw, err := suite.typed.Collection.Collection.CountDocuments(context.Background(), NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), raw)
+	deleted, err := suite.typed.FindDeleted(SimpleItem2.Filter())
+	suite.Require().NoError(err)
+	suite.Equal("two", deleted.Alpha)
+	_, err = suite.typed.FindDeleted(SimpleItem1.Filter())
+	suite.True(IsNotFound(err))
+}
+
+func (suite *softDeleteDbTestSuite) TestDeleteAllRestore() {
+	suite.Require().NoError(suite.typed.DeleteAll())
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Zero(count)
+	suite.ErrorIs(suite.typed.Update(SimpleItem1.Filter(), bson.M{"$inc": bson.M{"delta": 1}}), ErrNoItemMatch)
+	suite.Require().NoError(suite.typed.Restore(SimpleItem1.Filter(), false))
+	suite.ErrorIs(suite.typed.Restore(SimpleItem1.Filter(), false), ErrNoItemMatch)
+	suite.NoError(suite.typed.Restore(SimpleItem1.Filter(), true))
+	item, err := suite.typed.Find(SimpleItem1.Filter())
+	suite.Require().NoError(err)
+	suite.Equal("one", item.Alpha)
+	count, err = suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *softDeleteDbTestSuite) TestFindOneAndDelete() {
+	item, err := suite.typed.FindOneAndDelete(NoFilter(),
+		options.FindOneAndDelete().SetSort(bson.D{{Key: "bravo", Value: -1}}))
+	suite.Require().NoError(err)
+	suite.Equal("three", item.Alpha)
+	_, err = suite.typed.Find(SimpleItem3.Filter())
+	suite.True(IsNotFound(err))
+	_, err = suite.typed.FindDeleted(SimpleItem3.Filter())
+	suite.NoError(err)
+}
+
+func (suite *softDeleteDbTestSuite) TestBulk() {
+	suite.Require().NoError(suite.typed.Delete(SimpleItem3.Filter(), false))
+	result, err := suite.typed.Bulk().
+		Delete(SimpleItem1.Filter()).
+		Update(SimpleItem2.Filter(), bson.M{"$inc": bson.M{"delta": 1}}, false).
+		Update(SimpleItem3.Filter(), bson.M{"$inc": bson.M{"delta": 1}}, false).
+		Execute()
+	suite.Require().NoError(err)
+	suite.Zero(result.Deleted)
+	suite.Equal(int64(2), result.Modified)
+	_, err = suite.typed.FindDeleted(SimpleItem1.Filter())
+	suite.NoError(err)
+	deleted, err := suite.typed.FindDeleted(SimpleItem3.Filter())
+	suite.Require().NoError(err)
+	suite.Zero(deleted.Delta)
+	raw, err := suite.typed.Collection.Collection.CountDocuments(context.Background(), NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), raw)
+}
+
+func (suite *softDeleteDbTestSuite) TestPurge() {
+	suite.Require().NoError(suite.typed.Delete(SimpleItem1.Filter(), false))
+	purged, err := suite.typed.Purge(time.Hour)
+	suite.Require().NoError(err)
+	suite.Zero(purged)
+	time.Sleep(10 * time.Millisecond)
+	purged, err = suite.typed.Purge(time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), purged)
+	_, err = suite.typed.FindDeleted(SimpleItem1.Filter())
+	suite.True(IsNotFound(err))
+}
+
+func (suite *softDeleteDbTestSuite) TestNotConfigured() {
+	plain := ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollection)
+	suite.False(plain.SoftDeleted())
+	suite.ErrorIs(plain.Restore(SimpleItem1.Filter(), true), ErrNoSoftDelete)
+	_, err := plain.FindDeleted(SimpleItem1.Filter())
+	suite.ErrorIs(err, ErrNoSoftDelete)
+	_, err = plain.Purge(time.Hour)
+	suite.ErrorIs(err, ErrNoSoftDelete)
+}
@@ -0,0 +1,50 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type softDeleteTestSuite struct {
+	suite.Suite
+}
+
+func TestSoftDeleteSuite(t *testing.T) {
+	suite.Run(t, new(softDeleteTestSuite))
+}
+
+func (suite *softDeleteTestSuite) TestActiveFilter() {
+	filter := bson.D{{Key: "alpha", Value: "one"}}
+	plain := &Collection{}
+	suite.False(plain.SoftDeleted())
+	suite.Equal(filter, plain.activeFilter(filter))
+	soft := &Collection{softDelete: DefaultSoftDeleteField}
+	suite.True(soft.SoftDeleted())
+	suite.Equal(bson.D{
+		{Key: "alpha", Value: "one"},
+		{Key: "deletedAt", Value: nil},
+	}, soft.activeFilter(filter))
+	suite.Len(filter, 1, "original filter not changed")
+	suite.Equal(bson.D{{Key: "deletedAt", Value: nil}}, soft.activeFilter(nil))
+	suite.Equal(bson.D{{Key: "deletedAt", Value: nil}}, soft.activeFilter(NoFilter()))
+}
+
+func (suite *softDeleteTestSuite) TestDeletedFilter() {
+	soft := &Collection{softDelete: "removed"}
+	suite.Equal(bson.D{
+		{Key: "alpha", Value: "one"},
+		{Key: "removed", Value: bson.D{{Key: "$type", Value: "date"}}},
+	}, soft.deletedFilter(bson.D{{Key: "alpha", Value: "one"}}))
+}
+
+func (suite *softDeleteTestSuite) TestAddConditionCombined() {
+	condition := bson.E{Key: "deletedAt", Value: nil}
+	existing := bson.D{{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: true}}}}
+	suite.Equal(bson.D{{Key: "$and", Value: bson.A{existing, bson.D{condition}}}},
+		addCondition(existing, condition))
+	other := bson.M{"alpha": "one"}
+	suite.Equal(bson.D{{Key: "$and", Value: bson.A{other, bson.D{condition}}}},
+		addCondition(other, condition))
+}
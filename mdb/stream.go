@@ -0,0 +1,74 @@
+package mdb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StreamResult is a single result received from a Stream channel.
+// Exactly one of Item or Err is set.
+type StreamResult[T any] struct {
+	Item *T
+	Err  error
+}
+
+// Stream returns a channel that receives the items matching the filter and a function that stops the stream.
+// The channel is unbuffered so the cursor is only read as fast as the consumer receives items.
+// An error is sent as the final result before the channel is closed.
+// Always call the stop function, usually with defer, so that a consumer that stops early
+// releases the goroutine reading the cursor. Stopping closes the cursor and the channel,
+// as does ending the context.
+// Each item is decoded into a new object so the consumer may keep it.
+func (c *TypedCollection[T]) Stream(
+	ctx context.Context, filter bson.D, opts ...*options.FindOptions) (<-chan StreamResult[T], context.CancelFunc) {
+	ctx, stop := context.WithCancel(ctx)
+	results := make(chan StreamResult[T])
+	go func() {
+		defer close(results)
+		cursor, err := c.Collection.Collection.Find(ctx, c.activeFilter(filter), opts...)
+		if err != nil {
+			sendResult(ctx, results, StreamResult[T]{Err: c.opError("stream", filter, err)})
+			return
+		}
+		streamCursor(ctx, cursor, results, func(err error) error {
+			return c.opError("stream", filter, err)
+		})
+	}()
+	return results, stop
+}
+
+// streamCursor sends the documents in the cursor to the channel until the cursor or context ends.
+// Errors are passed through the wrap function before they are sent.
+// The cursor is always closed, the channel is not.
+func streamCursor[T any](
+	ctx context.Context, cursor *mongo.Cursor, results chan<- StreamResult[T], wrap func(err error) error) {
+	defer func() { _ = cursor.Close(context.Background()) }()
+
+	for cursor.Next(ctx) {
+		item, err := decodeItem[T](ctx, cursor.Decode)
+		if err != nil {
+			sendResult(ctx, results, StreamResult[T]{Err: wrap(err)})
+			return
+		}
+		if !sendResult(ctx, results, StreamResult[T]{Item: item}) {
+			return
+		}
+	}
+	if err := cursor.Err(); err != nil && ctx.Err() == nil {
+		sendResult(ctx, results, StreamResult[T]{Err: wrap(fmt.Errorf("iterate cursor: %w", err))})
+	}
+}
+
+// sendResult sends the result unless the context ends first.
+func sendResult[T any](ctx context.Context, results chan<- StreamResult[T], result StreamResult[T]) bool {
+	select {
+	case results <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,107 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type streamTestSuite struct {
+	suite.Suite
+}
+
+func TestStreamSuite(t *testing.T) {
+	suite.Run(t, new(streamTestSuite))
+}
+
+func (suite *streamTestSuite) TestAll() {
+	var alpha []string
+	for result := range suite.stream(context.Background(), suite.cursor(3, nil)) {
+		suite.Require().NoError(result.Err)
+		alpha = append(alpha, result.Item.Alpha)
+	}
+	suite.Equal([]string{"Alpha #0", "Alpha #1", "Alpha #2"}, alpha)
+}
+
+func (suite *streamTestSuite) TestBackpressure() {
+	cursor := suite.cursor(10, nil)
+	results := make(chan StreamResult[SimpleItem])
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		streamCursor(context.Background(), cursor, results, sameError)
+	}()
+	result := <-results
+	suite.Equal("Alpha #0", result.Item.Alpha)
+	// The producer is blocked until the next item is received.
+	select {
+	case <-done:
+		suite.Fail("stream finished without consumer")
+	default:
+	}
+	count := 1
+	for range results {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	<-done
+	suite.Equal(10, count)
+}
+
+func (suite *streamTestSuite) TestCancelClosesCursor() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cursor := suite.cursor(10, nil)
+	results := make(chan StreamResult[SimpleItem])
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		streamCursor(ctx, cursor, results, sameError)
+	}()
+	result := <-results
+	suite.Require().NoError(result.Err)
+	cancel()
+	<-done
+	suite.False(cursor.Next(context.Background()))
+}
+
+func (suite *streamTestSuite) TestCursorError() {
+	failure := errors.New("cursor failure")
+	var errs []error
+	for result := range suite.stream(context.Background(), suite.cursor(3, failure)) {
+		suite.Nil(result.Item)
+		errs = append(errs, result.Err)
+	}
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], failure)
+}
+
+func (suite *streamTestSuite) stream(ctx context.Context, cursor *mongo.Cursor) <-chan StreamResult[SimpleItem] {
+	results := make(chan StreamResult[SimpleItem])
+	go func() {
+		defer close(results)
+		streamCursor(ctx, cursor, results, sameError)
+	}()
+	return results
+}
+
+func (suite *streamTestSuite) cursor(count int, err error) *mongo.Cursor {
+	documents := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		documents[i] = bson.D{{Key: "alpha", Value: fmt.Sprintf("Alpha #%d", i)}}
+	}
+	cursor, cursorErr := mongo.NewCursorFromDocuments(documents, err, nil)
+	suite.Require().NoError(cursorErr)
+	return cursor
+}
+
+// sameError returns the error unchanged for tests that don't check wrapping.
+func sameError(err error) error {
+	return err
+}
@@ -0,0 +1,92 @@
+package mdb
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// CreatedAtField is the name of the creation time field used by Timestamps.
+	CreatedAtField = "createdAt"
+
+	// UpdatedAtField is the name of the update time field used by Timestamps.
+	UpdatedAtField = "updatedAt"
+)
+
+// Timestamped provides an interface to items with creation and update times
+// maintained by TypedCollection operations.
+// When embedding this always use:
+//
+//	mdb.Timestamps `bson:"inline"`
+type Timestamped interface {
+	CreatedTime() time.Time
+	UpdatedTime() time.Time
+	SetCreatedTime(created time.Time)
+	SetUpdatedTime(updated time.Time)
+}
+
+// Timestamps instantiates the Timestamped interface.
+type Timestamps struct {
+	CreatedAt time.Time `bson:"createdAt,omitempty"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty"`
+}
+
+// CreatedTime returns the time an item was created.
+func (ts *Timestamps) CreatedTime() time.Time {
+	return ts.CreatedAt
+}
+
+// UpdatedTime returns the time an item was last updated.
+func (ts *Timestamps) UpdatedTime() time.Time {
+	return ts.UpdatedAt
+}
+
+// SetCreatedTime sets the time an item was created.
+func (ts *Timestamps) SetCreatedTime(created time.Time) {
+	ts.CreatedAt = created
+}
+
+// SetUpdatedTime sets the time an item was last updated.
+func (ts *Timestamps) SetUpdatedTime(updated time.Time) {
+	ts.UpdatedAt = updated
+}
+
+// currentUpdatedAt returns the $currentDate element that sets the update time.
+func currentUpdatedAt() bson.E {
+	return bson.E{Key: UpdatedAtField, Value: true}
+}
+
+// timestampNow returns the current time at the millisecond precision stored by Mongo.
+func timestampNow() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// touchCreatedItem sets the timestamps for a new item if it is Timestamped.
+func touchCreatedItem(item interface{}) {
+	if stamped, ok := item.(Timestamped); ok {
+		touchCreated(stamped)
+	}
+}
+
+// stampedChanges adds setting the update time to the changes if the collection type T is Timestamped.
+// Operator documents get a $currentDate operator and update pipelines get a final $set stage,
+// both of which use the server time.
+func stampedChanges[T any](changes interface{}) (interface{}, error) {
+	if _, ok := any(new(T)).(Timestamped); !ok {
+		return changes, nil
+	}
+	if pipeline, ok := appendStage(changes, bson.D{{Key: "$set", Value: bson.D{{Key: UpdatedAtField, Value: "$$NOW"}}}}); ok {
+		return pipeline, nil
+	}
+	return addOperator(changes, "$currentDate", currentUpdatedAt())
+}
+
+// touchCreated sets the timestamps for a new item.
+func touchCreated(stamped Timestamped) {
+	now := timestampNow()
+	if stamped.CreatedTime().IsZero() {
+		stamped.SetCreatedTime(now)
+	}
+	stamped.SetUpdatedTime(now)
+}
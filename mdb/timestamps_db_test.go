@@ -0,0 +1,190 @@
+//go:build database
+
+package mdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type timestampsDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[stampedItem]
+}
+
+func TestTimestampsDbSuite(t *testing.T) {
+	suite.Run(t, new(timestampsDbTestSuite))
+}
+
+func (suite *timestampsDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[stampedItem](&suite.AccessTestSuite, testCollectionTimestamps)
+}
+
+func (suite *timestampsDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *timestampsDbTestSuite) filter(alpha string) bson.D {
+	return bson.D{{Key: "alpha", Value: alpha}}
+}
+
+func (suite *timestampsDbTestSuite) TestCreate() {
+	before := time.Now().Add(-time.Second)
+	item := &stampedItem{Alpha: "one"}
+	suite.Require().NoError(suite.typed.Create(item))
+	suite.False(item.CreatedAt.IsZero())
+	found, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.True(found.CreatedAt.After(before))
+	suite.True(found.CreatedAt.Equal(item.CreatedAt))
+	suite.True(found.UpdatedAt.Equal(found.CreatedAt))
+}
+
+func (suite *timestampsDbTestSuite) TestCreateMany() {
+	result, err := suite.typed.CreateMany([]*stampedItem{{Alpha: "one"}, {Alpha: "two"}})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), result.Inserted)
+	suite.NoError(suite.typed.Iterate(NoFilter(), func(item *stampedItem) error {
+		suite.False(item.CreatedAt.IsZero())
+		suite.False(item.UpdatedAt.IsZero())
+		return nil
+	}))
+}
+
+func (suite *timestampsDbTestSuite) TestFindOrCreate() {
+	item := &stampedItem{Alpha: "one"}
+	created, err := suite.typed.FindOrCreate(suite.filter("one"), item)
+	suite.Require().NoError(err)
+	suite.False(created.CreatedAt.IsZero())
+	suite.True(item.CreatedAt.IsZero(), "specified item not changed")
+	time.Sleep(5 * time.Millisecond)
+	found, err := suite.typed.FindOrCreate(suite.filter("one"), item)
+	suite.Require().NoError(err)
+	suite.True(found.CreatedAt.Equal(created.CreatedAt))
+	suite.True(found.UpdatedAt.Equal(created.UpdatedAt))
+}
+
+func (suite *timestampsDbTestSuite) TestUpdate() {
+	suite.Require().NoError(suite.typed.Create(&stampedItem{Alpha: "one"}))
+	created, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	suite.Require().NoError(suite.typed.Update(suite.filter("one"), bson.M{"$inc": bson.M{"bravo": 1}}))
+	updated, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.Equal(1, updated.Bravo)
+	suite.True(updated.CreatedAt.Equal(created.CreatedAt))
+	suite.True(updated.UpdatedAt.After(created.UpdatedAt))
+}
+
+func (suite *timestampsDbTestSuite) TestUpdatePipeline() {
+	suite.Require().NoError(suite.typed.Create(&stampedItem{Alpha: "one", Bravo: 1}))
+	created, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	suite.Require().NoError(suite.typed.Update(suite.filter("one"),
+		NewPipeline().Stage("$set", bson.D{{Key: "bravo", Value: bson.D{{Key: "$add", Value: bson.A{"$bravo", 1}}}}})))
+	updated, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.Equal(2, updated.Bravo)
+	suite.True(updated.UpdatedAt.After(created.UpdatedAt))
+}
+
+func (suite *timestampsDbTestSuite) TestReplace() {
+	suite.Require().NoError(suite.typed.Create(&stampedItem{Alpha: "one"}))
+	item, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	created := item.CreatedAt
+	previous := item.UpdatedAt
+	time.Sleep(5 * time.Millisecond)
+	item.Bravo = 7
+	suite.Require().NoError(suite.typed.Replace(suite.filter("one"), item))
+	suite.True(item.UpdatedAt.After(previous))
+	found, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.Equal(7, found.Bravo)
+	suite.True(found.CreatedAt.Equal(created))
+	suite.True(found.UpdatedAt.After(previous))
+	// Upsert sets creation time:
+	suite.Require().NoError(suite.typed.Replace(suite.filter("two"), &stampedItem{Alpha: "two"},
+		options.Update().SetUpsert(true)))
+	found, err = suite.typed.Find(suite.filter("two"))
+	suite.Require().NoError(err)
+	suite.False(found.CreatedAt.IsZero())
+	suite.False(found.UpdatedAt.IsZero())
+}
+
+func (suite *timestampsDbTestSuite) TestFindOneAndModify() {
+	suite.Require().NoError(suite.typed.Create(&stampedItem{Alpha: "one"}))
+	created, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	updated, err := suite.typed.FindOneAndUpdate(suite.filter("one"),
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "bravo", Value: 1}}}}, options.After)
+	suite.Require().NoError(err)
+	suite.True(updated.CreatedAt.Equal(created.CreatedAt))
+	suite.True(updated.UpdatedAt.After(created.UpdatedAt))
+	time.Sleep(5 * time.Millisecond)
+	updated.Bravo = 7
+	replaced, err := suite.typed.FindOneAndReplace(suite.filter("one"), updated, options.After)
+	suite.Require().NoError(err)
+	suite.Equal(7, replaced.Bravo)
+	suite.True(replaced.CreatedAt.Equal(created.CreatedAt))
+	suite.True(replaced.UpdatedAt.After(created.UpdatedAt))
+	// A failed replacement restores the item timestamps:
+	missing := &stampedItem{Alpha: "two"}
+	_, err = suite.typed.FindOneAndReplace(suite.filter("two"), missing, options.After)
+	suite.True(IsNotFound(err))
+	suite.True(missing.CreatedAt.IsZero())
+	suite.True(missing.UpdatedAt.IsZero())
+}
+
+func (suite *timestampsDbTestSuite) TestBulk() {
+	suite.Require().NoError(suite.typed.Create(&stampedItem{Alpha: "one"}))
+	created, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = suite.typed.Bulk().
+		Update(suite.filter("one"), bson.D{{Key: "$inc", Value: bson.D{{Key: "bravo", Value: 1}}}}, false).
+		Execute()
+	suite.Require().NoError(err)
+	updated, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.Equal(1, updated.Bravo)
+	suite.True(updated.UpdatedAt.After(created.UpdatedAt))
+	time.Sleep(5 * time.Millisecond)
+	fresh := &stampedItem{Alpha: "two"}
+	_, err = suite.typed.Bulk().
+		Replace(suite.filter("one"), updated, false).
+		Replace(suite.filter("two"), fresh, true).
+		Execute()
+	suite.Require().NoError(err)
+	suite.False(fresh.CreatedAt.IsZero())
+	replaced, err := suite.typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	suite.True(replaced.CreatedAt.Equal(created.CreatedAt))
+	suite.True(replaced.UpdatedAt.Equal(updated.UpdatedAt))
+	suite.True(replaced.UpdatedAt.After(created.UpdatedAt))
+	inserted, err := suite.typed.Find(suite.filter("two"))
+	suite.Require().NoError(err)
+	suite.True(inserted.CreatedAt.Equal(fresh.CreatedAt))
+}
+
+func (suite *timestampsDbTestSuite) TestReplaceVersioned() {
+	typed := ConnectTypedCollectionHelper[stampedVersionedItem](&suite.AccessTestSuite, testCollectionVersioned)
+	defer func() { suite.NoError(typed.DeleteAll()) }()
+	suite.Require().NoError(typed.Create(&stampedVersionedItem{Alpha: "one"}))
+	item, err := typed.Find(suite.filter("one"))
+	suite.Require().NoError(err)
+	stale := *item
+	previous := item.UpdatedAt
+	suite.Require().NoError(typed.Replace(suite.filter("one"), item))
+	suite.Equal(int64(1), item.CurrentVersion())
+	suite.ErrorIs(typed.Replace(suite.filter("one"), &stale), ErrVersionConflict)
+	suite.True(stale.UpdatedAt.Equal(previous), "update time restored after failure")
+}
@@ -0,0 +1,113 @@
+package mdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type timestampsTestSuite struct {
+	suite.Suite
+}
+
+func TestTimestampsSuite(t *testing.T) {
+	suite.Run(t, new(timestampsTestSuite))
+}
+
+type stampedItem struct {
+	Identity   `bson:"inline"`
+	Timestamps `bson:"inline"`
+	Alpha      string
+	Bravo      int
+}
+
+type stampedVersionedItem struct {
+	Identity   `bson:"inline"`
+	Version    `bson:"inline"`
+	Timestamps `bson:"inline"`
+	Alpha      string
+}
+
+func (suite *timestampsTestSuite) TestTimestamps() {
+	item := &stampedItem{Alpha: "one"}
+	var stamped Timestamped = item
+	suite.True(stamped.CreatedTime().IsZero())
+	suite.True(stamped.UpdatedTime().IsZero())
+	raw, err := bson.Marshal(item)
+	suite.Require().NoError(err)
+	_, err = bson.Raw(raw).LookupErr(CreatedAtField)
+	suite.Error(err, "zero time omitted")
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	stamped.SetCreatedTime(created)
+	stamped.SetUpdatedTime(created.Add(time.Hour))
+	suite.Equal(created, item.CreatedAt)
+	suite.Equal(created.Add(time.Hour), item.UpdatedAt)
+	raw, err = bson.Marshal(item)
+	suite.Require().NoError(err)
+	suite.Equal(created, bson.Raw(raw).Lookup(CreatedAtField).Time().UTC())
+	suite.Equal(created.Add(time.Hour), bson.Raw(raw).Lookup(UpdatedAtField).Time().UTC())
+}
+
+func (suite *timestampsTestSuite) TestTouchCreated() {
+	before := time.Now().Add(-time.Millisecond)
+	item := &stampedItem{}
+	touchCreated(item)
+	suite.True(item.CreatedAt.After(before))
+	suite.Equal(item.CreatedAt, item.UpdatedAt)
+	suite.Equal(item.CreatedAt, item.CreatedAt.Truncate(time.Millisecond))
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	item.CreatedAt = created
+	touchCreated(item)
+	suite.Equal(created, item.CreatedAt, "existing creation time kept")
+	suite.True(item.UpdatedAt.After(before))
+}
+
+func (suite *timestampsTestSuite) TestAddCurrentDate() {
+	changes, err := addOperator(bson.M{"$set": bson.M{"alpha": "two"}}, "$currentDate", currentUpdatedAt())
+	suite.Require().NoError(err)
+	suite.Equal(bson.M{
+		"$set":         bson.M{"alpha": "two"},
+		"$currentDate": bson.D{{Key: UpdatedAtField, Value: true}},
+	}, changes)
+	_, err = addOperator(bson.A{bson.D{{Key: "$set", Value: bson.D{}}}}, "$currentDate", currentUpdatedAt())
+	suite.Error(err)
+}
+
+func (suite *timestampsTestSuite) TestStampedChanges() {
+	set := bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "two"}}}}
+	changes, err := stampedChanges[SimpleItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(set, changes, "not Timestamped")
+	changes, err = stampedChanges[stampedItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(append(set, bson.E{Key: "$currentDate", Value: bson.D{currentUpdatedAt()}}), changes)
+	stage := bson.D{{Key: "$set", Value: bson.D{{Key: UpdatedAtField, Value: "$$NOW"}}}}
+	pipeline := mongo.Pipeline{set}
+	changes, err = stampedChanges[stampedItem](pipeline)
+	suite.Require().NoError(err)
+	suite.Equal(mongo.Pipeline{set, stage}, changes)
+	suite.Len(pipeline, 1, "pipeline not changed")
+	changes, err = stampedChanges[stampedItem](NewPipeline().Stage("$set", bson.D{}))
+	suite.Require().NoError(err)
+	suite.Len(changes, 2)
+	changes, err = stampedChanges[stampedItem](bson.A{set})
+	suite.Require().NoError(err)
+	suite.Equal(bson.A{set, stage}, changes)
+}
+
+func (suite *timestampsTestSuite) TestUpdateChanges() {
+	set := bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "two"}}}}
+	changes, err := updateChanges[SimpleItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(set, changes)
+	changes, err = updateChanges[stampedVersionedItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(append(set,
+		bson.E{Key: "$currentDate", Value: bson.D{currentUpdatedAt()}},
+		bson.E{Key: "$inc", Value: bson.D{{Key: VersionField, Value: 1}}}), changes)
+	_, err = updateChanges[stampedItem](struct{}{})
+	suite.Error(err)
+}
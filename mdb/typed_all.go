@@ -0,0 +1,62 @@
+//go:build go1.23
+
+package mdb
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All returns an iterator over the items matching the filter for use with range (Go 1.23 and later):
+//
+//	for item, err := range collection.All(ctx, filter) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The query is not run until iteration starts and is run again for each iteration.
+// An error is yielded once with a nil item and ends iteration.
+// The cursor is closed when iteration ends, including when the loop exits early.
+// Each item is decoded into a new object so the loop may keep it.
+func (c *TypedCollection[T]) All(ctx context.Context, filter bson.D, opts ...*options.FindOptions) iter.Seq2[*T, error] {
+	return cursorSeq[T](ctx, func(ctx context.Context) (*mongo.Cursor, error) {
+		return c.Collection.Collection.Find(ctx, c.activeFilter(filter), opts...)
+	}, func(err error) error {
+		return c.opError("all", filter, err)
+	})
+}
+
+// cursorSeq returns an iterator over the documents in a cursor opened when iteration starts.
+// Errors are passed through the wrap function before they are yielded.
+func cursorSeq[T any](
+	ctx context.Context, open func(ctx context.Context) (*mongo.Cursor, error), wrap func(err error) error) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		cursor, err := open(ctx)
+		if err != nil {
+			yield(nil, wrap(err))
+			return
+		}
+		defer func() { _ = cursor.Close(context.Background()) }()
+
+		for cursor.Next(ctx) {
+			item, err := decodeItem[T](ctx, cursor.Decode)
+			if err != nil {
+				yield(nil, wrap(err))
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			yield(nil, wrap(fmt.Errorf("iterate cursor: %w", err)))
+		}
+	}
+}
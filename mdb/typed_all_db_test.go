@@ -0,0 +1,30 @@
+//go:build database && go1.23
+
+package mdb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (suite *typedTestSuite) TestAll() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	var alpha []string
+	sort := options.Find().SetSort(bson.D{{Key: "alpha", Value: 1}})
+	for item, err := range suite.typed.All(context.Background(), NoFilter(), sort) {
+		suite.Require().NoError(err)
+		alpha = append(alpha, item.Alpha)
+	}
+	suite.Equal([]string{"one", "three", "two"}, alpha)
+	count := 0
+	for _, err := range suite.typed.All(context.Background(), NoFilter()) {
+		suite.Require().NoError(err)
+		count++
+		break
+	}
+	suite.Equal(1, count)
+}
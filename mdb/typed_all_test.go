@@ -0,0 +1,93 @@
+//go:build go1.23
+
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type allTestSuite struct {
+	suite.Suite
+}
+
+func TestAllSuite(t *testing.T) {
+	suite.Run(t, new(allTestSuite))
+}
+
+func (suite *allTestSuite) TestAll() {
+	var alpha []string
+	for item, err := range cursorSeq[SimpleItem](context.Background(), suite.open(3, nil, nil), sameError) {
+		suite.Require().NoError(err)
+		alpha = append(alpha, item.Alpha)
+	}
+	suite.Equal([]string{"Alpha #0", "Alpha #1", "Alpha #2"}, alpha)
+}
+
+func (suite *allTestSuite) TestBreakClosesCursor() {
+	var cursor *mongo.Cursor
+	count := 0
+	for _, err := range cursorSeq[SimpleItem](context.Background(), suite.open(10, nil, &cursor), sameError) {
+		suite.Require().NoError(err)
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	suite.Equal(2, count)
+	suite.Require().NotNil(cursor)
+	suite.False(cursor.Next(context.Background()))
+}
+
+func (suite *allTestSuite) TestOpenError() {
+	failure := errors.New("failure")
+	count := 0
+	for item, err := range cursorSeq[SimpleItem](context.Background(),
+		func(ctx context.Context) (*mongo.Cursor, error) {
+			return nil, failure
+		}, func(err error) error {
+			return fmt.Errorf("wrapped: %w", err)
+		}) {
+		suite.Nil(item)
+		suite.ErrorIs(err, failure)
+		suite.ErrorContains(err, "wrapped")
+		count++
+	}
+	suite.Equal(1, count)
+}
+
+func (suite *allTestSuite) TestCursorError() {
+	failure := errors.New("cursor failure")
+	var lastErr error
+	count := 0
+	for item, err := range cursorSeq[SimpleItem](context.Background(), suite.open(3, failure, nil), sameError) {
+		if err != nil {
+			lastErr = err
+		} else {
+			suite.NotNil(item)
+			count++
+		}
+	}
+	suite.Zero(count)
+	suite.ErrorIs(lastErr, failure)
+}
+
+func (suite *allTestSuite) open(count int, err error, opened **mongo.Cursor) func(ctx context.Context) (*mongo.Cursor, error) {
+	return func(ctx context.Context) (*mongo.Cursor, error) {
+		documents := make([]interface{}, count)
+		for i := 0; i < count; i++ {
+			documents[i] = bson.D{{Key: "alpha", Value: fmt.Sprintf("Alpha #%d", i)}}
+		}
+		cursor, cursorErr := mongo.NewCursorFromDocuments(documents, err, nil)
+		if opened != nil {
+			*opened = cursor
+		}
+		return cursor, cursorErr
+	}
+}
@@ -3,12 +3,18 @@ package mdb
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/madkins23/go-mongo/mdb/query"
 )
 
 // TypedCollection uses reflection to properly create objects returned from Mongo.
+// Item types may implement lifecycle hooks, see BeforeCreator, AfterCreator,
+// BeforeUpdater, AfterFinder, and BeforeDeleter.
 type TypedCollection[T any] struct {
 	Collection
 }
@@ -24,50 +30,303 @@ func ConnectTypedCollection[T any](access *Access, definition *CollectionDefinit
 
 // Find an item in the database.
 // Will return an interface to an item of the collection's type.
-func (c *TypedCollection[T]) Find(filter bson.D) (*T, error) {
-	result := c.FindOne(c.ctx, filter)
+// Options may be used to specify sort, skip, projection, hint, collation, or maxTime.
+// Fields excluded by a projection are left with zero values.
+func (c *TypedCollection[T]) Find(filter bson.D, opts ...*options.FindOneOptions) (*T, error) {
+	result := c.FindOne(c.ctx, c.activeFilter(filter), opts...)
 	if err := result.Err(); err != nil {
-		if IsNotFound(err) {
-			return nil, fmt.Errorf("no item '%v': %w", filter, err)
-		}
-		return nil, fmt.Errorf("find item '%v': %w", filter, err)
+		return nil, c.opError("find", filter, err)
 	}
 	item := new(T)
 	if err := result.Decode(item); err != nil {
-		return item, fmt.Errorf("decode item: %w", err)
+		return item, c.opError("find", filter, fmt.Errorf("decode item: %w", err))
+	}
+	if err := afterFind(c.ctx, item); err != nil {
+		return nil, c.opError("find", filter, err)
 	}
 
 	return item, nil
 }
 
 // FindOrCreate returns an existing cacheable object or creates it if it does not already exist.
+// If the item is Timestamped a created item has its timestamps set.
+// Creation hooks are called on a copy of the item, the specified item is not changed.
 func (c *TypedCollection[T]) FindOrCreate(filter bson.D, item *T) (*T, error) {
 	// Can't inherit from TypedCollection here, must redo the algorithm due to typing.
+	copied := *item
+	item = &copied
+	if err := beforeCreate(c.ctx, item); err != nil {
+		return nil, c.opError("find or create", filter, err)
+	}
+	touchCreatedItem(item)
 	upsert := true
-	if err := c.Update(filter, bson.M{"$setOnInsert": item}, &options.UpdateOptions{Upsert: &upsert}); err != nil {
-		if !errors.Is(err, errNoItemModified) { // OK if item already exists.
-			return nil, fmt.Errorf("update $setOnInsert: %w", err)
+	if err := c.Collection.Update(filter, bson.M{"$setOnInsert": item}, &options.UpdateOptions{Upsert: &upsert}); err != nil {
+		if !errors.Is(err, ErrNoItemModified) { // OK if item already exists.
+			return nil, err
 		}
+	} else if err = afterCreate(c.ctx, item); err != nil {
+		return nil, c.opError("find or create", filter, err)
 	}
 	return c.Find(filter)
 }
 
-// Iterate over a set of items, applying the specified function to each one.
-func (c *TypedCollection[T]) Iterate(filter bson.D, fn func(item *T) error) error {
-	if cursor, err := c.Collection.Collection.Find(c.ctx, filter); err != nil {
-		return fmt.Errorf("find items: %w", err)
-	} else {
-		item := new(T)
-		for cursor.Next(c.ctx) {
-			if err := cursor.Decode(item); err != nil {
-				return fmt.Errorf("decode item: %w", err)
-			}
-
-			if err := fn(item); err != nil {
-				return fmt.Errorf("apply function: %w", err)
-			}
+// Create item in DB.
+// If the item is Timestamped the creation time is set if it is zero and the update time is set.
+// Inserts can't use server time so these are set from the application clock.
+// The BeforeCreate hook is called before the timestamps are set.
+func (c *TypedCollection[T]) Create(item interface{}) error {
+	if err := beforeCreate(c.ctx, item); err != nil {
+		return c.opError("create", nil, err)
+	}
+	touchCreatedItem(item)
+	if err := c.Collection.Create(item); err != nil {
+		return err
+	}
+	return c.opError("create", nil, afterCreate(c.ctx, item))
+}
+
+// Update item referenced by filter by applying update operator expressions.
+// If the collection type is Timestamped the update time is set to the server time
+// and if it is Versioned the version is incremented,
+// in which case the changes must be a bson.D or bson.M of update operators or an update pipeline.
+// The creation time is not set for items inserted by upsert, use FindOrCreate instead.
+// Use UpdateVersion to only update an item with a known version.
+// If the filter matches more than one document mongo-go-driver will choose one to update.
+func (c *TypedCollection[T]) Update(filter, changes interface{}, opts ...*options.UpdateOptions) error {
+	changes, err := updateChanges[T](changes)
+	if err != nil {
+		return c.opError("update", filter, err)
+	}
+	return c.Collection.Update(filter, changes, opts...)
+}
+
+// FindOneAndUpdate atomically applies update operator expressions to an item and returns it.
+// Set returnDocument to options.Before or options.After to choose the item before or after the update.
+// Options may be used to specify upsert, sort (to choose among multiple matching items),
+// projection, hint, collation, or maxTime.
+// If an upserted item is inserted and returnDocument is options.Before, the result is nil with no error.
+// Timestamped and Versioned items are handled as for Update.
+func (c *TypedCollection[T]) FindOneAndUpdate(
+	filter bson.D, changes interface{}, returnDocument options.ReturnDocument,
+	opts ...*options.FindOneAndUpdateOptions) (*T, error) {
+	changes, err := updateChanges[T](changes)
+	if err != nil {
+		return nil, c.opError("find and update", filter, err)
+	}
+	opts = append(opts, options.FindOneAndUpdate().SetReturnDocument(returnDocument))
+	upsert := options.MergeFindOneAndUpdateOptions(opts...).Upsert
+	result := c.Collection.Collection.FindOneAndUpdate(c.ctx, c.activeFilter(filter), changes, opts...)
+	return c.modifiedItem(result, filter, upsertBefore(upsert, returnDocument), "find and update")
+}
+
+// FindOneAndReplace atomically replaces an item with the specified item and returns one of them.
+// Set returnDocument to options.Before or options.After to choose the item before or after replacement.
+// Options may be used to specify upsert, sort (to choose among multiple matching items),
+// projection, hint, collation, or maxTime.
+// If an upserted item is inserted and returnDocument is options.Before, the result is nil with no error.
+// Versioned items are handled as for Replace and Timestamped items as for ReplaceDocument.
+func (c *TypedCollection[T]) FindOneAndReplace(
+	filter bson.D, item *T, returnDocument options.ReturnDocument,
+	opts ...*options.FindOneAndReplaceOptions) (*T, error) {
+	if err := beforeUpdate(c.ctx, item); err != nil {
+		return nil, c.opError("find and replace", filter, err)
+	}
+	opts = append(opts, options.FindOneAndReplace().SetReturnDocument(returnDocument))
+	stamped, isStamped := any(item).(Timestamped)
+	var created, updated time.Time
+	if isStamped {
+		created, updated = stamped.CreatedTime(), stamped.UpdatedTime()
+		touchCreated(stamped)
+	}
+	var match interface{} = filter
+	versioned, isVersioned := any(item).(Versioned)
+	var version int64
+	if isVersioned {
+		version = versioned.CurrentVersion()
+		if version > 0 {
+			opts = append(opts, options.FindOneAndReplace().SetUpsert(false))
+		}
+		match = addCondition(filter, versionCondition(version))
+		versioned.SetVersion(version + 1)
+	}
+	upsert := options.MergeFindOneAndReplaceOptions(opts...).Upsert
+	result := c.Collection.Collection.FindOneAndReplace(c.ctx, c.activeFilter(match), item, opts...)
+	found, err := c.modifiedItem(result, filter, upsertBefore(upsert, returnDocument), "find and replace")
+	if err != nil && result.Err() != nil {
+		// Nothing was replaced.
+		if isStamped {
+			stamped.SetCreatedTime(created)
+			stamped.SetUpdatedTime(updated)
+		}
+		if isVersioned {
+			versioned.SetVersion(version)
+			return nil, c.versionError("find and replace", filter, err)
+		}
+	}
+	return found, err
+}
+
+// FindOneAndDelete atomically deletes an item and returns it.
+// Options may be used to specify sort (to choose among multiple matching items),
+// projection, hint, collation, or maxTime.
+// If soft delete is configured the item is marked as deleted instead of being removed.
+func (c *TypedCollection[T]) FindOneAndDelete(filter bson.D, opts ...*options.FindOneAndDeleteOptions) (*T, error) {
+	if err := beforeDelete[T](c.ctx, filter); err != nil {
+		return nil, c.opError("find and delete", filter, err)
+	}
+	if c.SoftDeleted() {
+		deleteOpts := options.MergeFindOneAndDeleteOptions(opts...)
+		updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+		updateOpts.Collation = deleteOpts.Collation
+		updateOpts.Comment = deleteOpts.Comment
+		updateOpts.MaxTime = deleteOpts.MaxTime
+		updateOpts.Projection = deleteOpts.Projection
+		updateOpts.Sort = deleteOpts.Sort
+		updateOpts.Hint = deleteOpts.Hint
+		updateOpts.Let = deleteOpts.Let
+		result := c.Collection.Collection.FindOneAndUpdate(
+			c.ctx, c.activeFilter(filter), c.softDeleteChanges(), updateOpts)
+		return c.modifiedItem(result, filter, false, "find and delete")
+	}
+
+	result := c.Collection.Collection.FindOneAndDelete(c.ctx, filter, opts...)
+	return c.modifiedItem(result, filter, false, "find and delete")
+}
+
+// updateChanges adds setting the update time and incrementing the version to the changes
+// if the collection type T is Timestamped or Versioned.
+func updateChanges[T any](changes interface{}) (interface{}, error) {
+	changes, err := stampedChanges[T](changes)
+	if err != nil {
+		return nil, err
+	}
+	return versionedChanges[T](changes)
+}
+
+// upsertBefore returns true if an upsert may insert an item that can't be returned.
+func upsertBefore(upsert *bool, returnDocument options.ReturnDocument) bool {
+	return upsert != nil && *upsert && returnDocument == options.Before
+}
+
+// modifiedItem decodes the item returned by a find and modify operation.
+// Set noneOK to accept a missing item, which is returned as nil.
+func (c *TypedCollection[T]) modifiedItem(result *mongo.SingleResult, filter bson.D, noneOK bool, op string) (*T, error) {
+	if err := result.Err(); err != nil {
+		if noneOK && IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, c.opError(op, filter, err)
+	}
+	item, err := decodeItem[T](c.ctx, result.Decode)
+	if err != nil {
+		return nil, c.opError(op, filter, err)
+	}
+
+	return item, nil
+}
+
+// Replace entire item referenced by filter with specified item.
+// If the item is Versioned the filter only matches the item with the same version,
+// which is incremented in the database and the item.
+// If the filter matches an item with a different version ErrVersionConflict is returned.
+// Upsert only applies to Versioned items with a zero version.
+// If the item is Timestamped the update time is set to the server time
+// and the item's update time to the approximate application time.
+// The creation time is set for an item inserted by upsert if it is zero.
+// If the filter matches more than one document mongo-go-driver will choose one to update.
+func (c *TypedCollection[T]) Replace(filter, item interface{}, opts ...*options.UpdateOptions) error {
+	if err := beforeUpdate(c.ctx, item); err != nil {
+		return c.opError("replace", filter, err)
+	}
+	changes := bson.D{{Key: "$set", Value: item}}
+	match := filter
+
+	stamped, isStamped := item.(Timestamped)
+	var updated time.Time
+	if isStamped {
+		updated = stamped.UpdatedTime()
+		if stamped.CreatedTime().IsZero() {
+			changes = append(changes, bson.E{Key: "$setOnInsert", Value: bson.D{{Key: CreatedAtField, Value: timestampNow()}}})
+		}
+		// Leave the update time out of $set as it is set by $currentDate.
+		stamped.SetUpdatedTime(time.Time{})
+		changes = append(changes, bson.E{Key: "$currentDate", Value: bson.D{currentUpdatedAt()}})
+	}
+
+	versioned, isVersioned := item.(Versioned)
+	var version int64
+	if isVersioned {
+		version = versioned.CurrentVersion()
+		if version > 0 {
+			opts = append(opts, options.Update().SetUpsert(false))
+		}
+		match = addCondition(filter, versionCondition(version))
+		versioned.SetVersion(version + 1)
+	}
+
+	err := c.Collection.Update(match, changes, opts...)
+	if isStamped {
+		if err == nil {
+			stamped.SetUpdatedTime(timestampNow())
+		} else {
+			stamped.SetUpdatedTime(updated)
+		}
+	}
+	if err != nil {
+		if isVersioned {
+			versioned.SetVersion(version)
+			return c.versionError("replace", filter, err)
 		}
+		return err
 	}
 
 	return nil
 }
+
+// Iterate over a set of items, applying the specified function to each one.
+// Options may be used to specify sort, limit, skip, projection, hint, collation, maxTime, or batch size.
+// Each item is decoded into a new object so the function may keep it
+// and fields excluded by a projection are left with zero values.
+// Return StopIteration from the function to end iteration early without an error.
+func (c *TypedCollection[T]) Iterate(filter bson.D, fn func(item *T) error, opts ...*options.FindOptions) error {
+	cursor, err := c.Collection.Collection.Find(c.ctx, c.activeFilter(filter), opts...)
+	if err != nil {
+		return c.opError("iterate", filter, err)
+	}
+
+	return c.opError("iterate", filter, iterateCursor(c.ctx, cursor, func(cursor *mongo.Cursor) error {
+		item, err := decodeItem[T](c.ctx, cursor.Decode)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(item); err != nil {
+			return fmt.Errorf("apply function: %w", err)
+		}
+		return nil
+	}))
+}
+
+// Delete item referenced by filter.
+// Set idempotent to true to avoid errors if the item does not exist.
+// If soft delete is configured the item is marked as deleted instead of being removed.
+func (c *TypedCollection[T]) Delete(filter bson.D, idempotent bool) error {
+	if err := beforeDelete[T](c.ctx, filter); err != nil {
+		return c.opError("delete", filter, err)
+	}
+	return c.Collection.Delete(filter, idempotent)
+}
+
+// DeleteAll items in the collection.
+// If soft delete is configured the items are marked as deleted instead of being removed.
+func (c *TypedCollection[T]) DeleteAll() error {
+	if err := beforeDelete[T](c.ctx, nil); err != nil {
+		return c.opError("delete all", nil, err)
+	}
+	return c.Collection.DeleteAll()
+}
+
+// Query returns a filter builder that checks field paths against the bson tags of the collection's type.
+func (c *TypedCollection[T]) Query() *query.Filter {
+	return query.For[T]()
+}
@@ -3,14 +3,21 @@
 package mdb
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/madkins23/go-type/reg"
+
+	"github.com/madkins23/go-mongo/mdb/query"
 )
 
 type typedTestSuite struct {
@@ -47,6 +54,14 @@ func (suite *typedTestSuite) TestCreateDuplicate() {
 	err = suite.typed.Create(SimpleItem1)
 	suite.Require().Error(err)
 	suite.Require().True(IsDuplicate(err))
+	info, ok := DuplicateKey(err)
+	suite.Require().True(ok)
+	suite.Equal("alpha_1", info.Index)
+	suite.Equal(bson.D{{Key: "alpha", Value: "one"}}, info.KeyValue)
+	var opErr *OpError
+	suite.Require().ErrorAs(err, &opErr)
+	suite.Equal("create", opErr.Op)
+	suite.Equal(testCollectionValidation.Name, opErr.Collection)
 }
 
 func (suite *typedTestSuite) TestFindNone() {
@@ -95,7 +110,7 @@ func (suite *typedTestSuite) TestCreateFindDelete() {
 	suite.True(IsNotFound(err))
 	suite.Nil(noItem)
 	err = suite.typed.Delete(SimpleItem2.Filter(), false)
-	suite.Require().Error(err)
+	suite.Require().ErrorIs(err, ErrNoItemMatch)
 	err = suite.typed.Delete(SimpleItem2.Filter(), true)
 	suite.Require().NoError(err)
 }
@@ -145,6 +160,150 @@ func (suite *typedTestSuite) TestIterateFiltered() {
 	suite.Equal([]string{"two"}, alpha)
 }
 
+func (suite *typedTestSuite) TestIterateKeepItems() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	var items []*SimpleItem
+	suite.NoError(suite.typed.Iterate(NoFilter(),
+		func(item *SimpleItem) error {
+			items = append(items, item)
+			return nil
+		}))
+	suite.Require().Len(items, 3)
+	suite.Equal("one", items[0].Alpha)
+	suite.Equal(1, items[0].Delta)
+	suite.Equal("two", items[1].Alpha)
+	suite.Zero(items[1].Delta) // not carried over from the previous item
+	suite.Equal("three", items[2].Alpha)
+}
+
+func (suite *typedTestSuite) TestIterateStop() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	count := 0
+	suite.NoError(suite.typed.Iterate(NoFilter(),
+		func(item *SimpleItem) error {
+			count++
+			return StopIteration
+		}))
+	suite.Equal(1, count)
+	failure := errors.New("failure")
+	suite.ErrorIs(suite.typed.Iterate(NoFilter(),
+		func(item *SimpleItem) error {
+			return failure
+		}), failure)
+}
+
+func (suite *typedTestSuite) TestIterateParallel() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	var lock sync.Mutex
+	var alpha []string
+	suite.NoError(suite.typed.IterateParallel(context.Background(), NoFilter(), 2,
+		func(ctx context.Context, item *SimpleItem) error {
+			lock.Lock()
+			defer lock.Unlock()
+			alpha = append(alpha, item.Alpha)
+			return nil
+		}))
+	suite.ElementsMatch([]string{"one", "two", "three"}, alpha)
+	alpha = nil
+	suite.NoError(suite.typed.IterateParallel(context.Background(), NoFilter(), 3,
+		func(ctx context.Context, item *SimpleItem) error {
+			alpha = append(alpha, item.Alpha)
+			return nil
+		}, &ParallelOptions[SimpleItem]{
+			Find: options.Find().SetSort(bson.D{{Key: "alpha", Value: 1}}),
+			Key:  func(item *SimpleItem) string { return "all" },
+		}))
+	suite.Equal([]string{"one", "three", "two"}, alpha)
+	failure := errors.New("failure")
+	suite.ErrorIs(suite.typed.IterateParallel(context.Background(), NoFilter(), 2,
+		func(ctx context.Context, item *SimpleItem) error {
+			return failure
+		}), failure)
+}
+
+func (suite *typedTestSuite) TestStream() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	var alpha []string
+	sort := options.Find().SetSort(bson.D{{Key: "alpha", Value: 1}})
+	results, stop := suite.typed.Stream(context.Background(), NoFilter(), sort)
+	defer stop()
+	for result := range results {
+		suite.Require().NoError(result.Err)
+		alpha = append(alpha, result.Item.Alpha)
+	}
+	suite.Equal([]string{"one", "three", "two"}, alpha)
+}
+
+func (suite *typedTestSuite) TestStreamStop() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	results, stop := suite.typed.Stream(context.Background(), NoFilter())
+	result := <-results
+	suite.Require().NoError(result.Err)
+	stop()
+	for range results {
+		// Drain until the channel is closed after stopping.
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	results, stop = suite.typed.Stream(ctx, NoFilter())
+	defer stop()
+	cancel()
+	for range results {
+		// Drain until the channel is closed after cancellation.
+	}
+}
+
+func (suite *typedTestSuite) TestIterateOptions() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	var items []*SimpleItem
+	suite.NoError(suite.typed.Iterate(NoFilter(),
+		func(item *SimpleItem) error {
+			items = append(items, item)
+			return nil
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "bravo", Value: -1}}).
+			SetSkip(1).
+			SetLimit(2).
+			SetBatchSize(1).
+			SetProjection(bson.D{{Key: "alpha", Value: 1}, {Key: "bravo", Value: 1}}).
+			SetHint(bson.D{{Key: "_id", Value: 1}}).
+			SetMaxTime(time.Second)))
+	suite.Require().Len(items, 2)
+	suite.Equal("two", items[0].Alpha)
+	suite.Equal(2, items[0].Bravo)
+	suite.Equal("one", items[1].Alpha)
+	for _, item := range items {
+		// Excluded by projection.
+		suite.Empty(item.Charlie)
+		suite.Zero(item.Delta)
+	}
+}
+
+func (suite *typedTestSuite) TestFindOptions() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	item, err := suite.typed.Find(NoFilter(), options.FindOne().
+		SetSort(bson.D{{Key: "bravo", Value: -1}}).
+		SetProjection(bson.D{{Key: "charlie", Value: 0}}).
+		SetCollation(&options.Collation{Locale: "en"}))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(item)
+	suite.Equal("two", item.Alpha)
+	suite.Empty(item.Charlie)
+	suite.NotEmpty(item.ID())
+}
+
 func (suite *typedTestSuite) TestReplace() {
 	suite.Require().NoError(suite.typed.Create(SimpleItem1))
 	item, err := suite.typed.Find(SimpleItem1.Filter())
@@ -167,7 +326,7 @@ func (suite *typedTestSuite) TestReplace() {
 	suite.Equal("xRay", item.Alpha)
 	// Replace with same value:
 	err = suite.typed.Replace(SimpleItem1x, SimpleItem1x)
-	suite.Require().ErrorIs(err, errNoItemModified)
+	suite.Require().ErrorIs(err, ErrNoItemModified)
 	item, err = suite.typed.Find(SimpleItem1x.Filter())
 	suite.Require().NoError(err)
 	suite.Require().NotNil(item)
@@ -178,7 +337,7 @@ func (suite *typedTestSuite) TestReplace() {
 	// No match for filter:
 	item, err = suite.typed.Find(SimpleItem3.Filter())
 	suite.True(IsNotFound(err))
-	suite.ErrorIs(suite.typed.Replace(SimpleItem3, SimpleItem3), errNoItemMatch)
+	suite.ErrorIs(suite.typed.Replace(SimpleItem3, SimpleItem3), ErrNoItemMatch)
 	// Upsert new item:
 	suite.NoError(suite.typed.Replace(NoFilter(), SimpleItem3))
 	item, err = suite.typed.Find(SimpleItem3.Filter())
@@ -190,6 +349,28 @@ func (suite *typedTestSuite) TestReplace() {
 	suite.Equal(itemID, item.ID())
 }
 
+func (suite *typedTestSuite) TestUpsert() {
+	result, err := suite.typed.Upsert(SimpleItem1.Filter(), SimpleItem1)
+	suite.Require().NoError(err)
+	suite.Equal(ItemCreated, result.Status)
+	item, err := suite.typed.Find(SimpleItem1.Filter())
+	suite.Require().NoError(err)
+	suite.Equal(1, item.Delta)
+	result, err = suite.typed.Upsert(SimpleItem1.Filter(), SimpleItem1)
+	suite.Require().NoError(err)
+	suite.Equal(ItemUnchanged, result.Status)
+	item.Delta = 0
+	result, err = suite.typed.Upsert(SimpleItem1.Filter(), item)
+	suite.Require().NoError(err)
+	suite.Equal(ItemReplaced, result.Status)
+	item, err = suite.typed.Find(SimpleItem1.Filter())
+	suite.Require().NoError(err)
+	suite.Zero(item.Delta, "omitted field removed")
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+}
+
 func (suite *typedTestSuite) TestStringValuesFor() {
 	typed, err := ConnectTypedCollection[SimpleItem](suite.access, testCollectionStringValues)
 	suite.Require().NoError(err)
@@ -212,6 +393,22 @@ func (suite *typedTestSuite) TestStringValuesFor() {
 	suite.Len(values, 0)
 }
 
+func (suite *typedTestSuite) TestQuery() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	filter, err := suite.typed.Query().
+		Gt("bravo", 1).
+		Or(query.New().Eq("alpha", "two"), query.New().Regex("charlie", "^three", "i")).
+		Build()
+	suite.Require().NoError(err)
+	count, err := suite.typed.Count(filter)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+	_, err = suite.typed.Query().Eq("alfa", "one").Build()
+	suite.ErrorIs(err, query.ErrUnknownField)
+}
+
 func (suite *typedTestSuite) TestUpdate() {
 	suite.Require().NoError(suite.typed.Create(SimpleItem1))
 	item, err := suite.typed.Find(SimpleItem1.Filter())
@@ -243,7 +440,102 @@ func (suite *typedTestSuite) TestUpdate() {
 	suite.ErrorIs(suite.typed.Update(SimpleItem3.Filter(), bson.M{
 		"$set": bson.M{"charlie": "Horse"},
 		"$inc": bson.M{"delta": 7},
-	}), errNoItemMatch)
+	}), ErrNoItemMatch)
+}
+
+func (suite *typedTestSuite) TestFindOneAndUpdate() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	inc := bson.M{"$inc": bson.M{"delta": 1}}
+	before, err := suite.typed.FindOneAndUpdate(SimpleItem1.Filter(), inc, options.Before)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(before)
+	suite.Equal(1, before.Delta)
+	after, err := suite.typed.FindOneAndUpdate(SimpleItem1.Filter(), inc, options.After)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(after)
+	suite.Equal(3, after.Delta)
+	suite.Equal(before.ID(), after.ID())
+	// No match for filter:
+	item, err := suite.typed.FindOneAndUpdate(SimpleItem3.Filter(), inc, options.After)
+	suite.True(IsNotFound(err))
+	suite.Nil(item)
+	// Upsert new item:
+	upsert := options.FindOneAndUpdate().SetUpsert(true)
+	setCharlie := bson.M{"$set": bson.M{"charlie": "Upserted"}}
+	item, err = suite.typed.FindOneAndUpdate(SimpleItem3.Filter(), setCharlie, options.Before, upsert)
+	suite.NoError(err)
+	suite.Nil(item)
+	item, err = suite.typed.FindOneAndUpdate(SimpleItem2.Filter(), setCharlie, options.After, upsert)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(item)
+	suite.Equal("two", item.Alpha)
+	suite.Equal("Upserted", item.Charlie)
+	count, err := suite.typed.Count(bson.D{{Key: "charlie", Value: "Upserted"}})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *typedTestSuite) TestFindOneAndUpdateSort() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	// Claim the pending item with the lowest bravo value each time.
+	pending := bson.D{{Key: "delta", Value: bson.D{{Key: "$ne", Value: 99}}}}
+	claim := bson.M{"$set": bson.M{"delta": 99}}
+	sorted := options.FindOneAndUpdate().SetSort(bson.D{{Key: "bravo", Value: 1}})
+	var alpha []string
+	for {
+		item, err := suite.typed.FindOneAndUpdate(pending, claim, options.After, sorted)
+		if IsNotFound(err) {
+			break
+		}
+		suite.Require().NoError(err)
+		suite.Equal(99, item.Delta)
+		alpha = append(alpha, item.Alpha)
+	}
+	suite.Equal([]string{"one", "two", "three"}, alpha)
+}
+
+func (suite *typedTestSuite) TestFindOneAndReplace() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	before, err := suite.typed.FindOneAndReplace(SimpleItem1.Filter(), SimpleItem1x, options.Before)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(before)
+	suite.Equal("one", before.Alpha)
+	after, err := suite.typed.FindOneAndReplace(SimpleItem1x.Filter(), SimpleItem2, options.After)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(after)
+	suite.Equal("two", after.Alpha)
+	suite.Equal(before.ID(), after.ID())
+	// No match for filter:
+	item, err := suite.typed.FindOneAndReplace(SimpleItem1.Filter(), SimpleItem3, options.After)
+	suite.True(IsNotFound(err))
+	suite.Nil(item)
+	// Upsert new item:
+	item, err = suite.typed.FindOneAndReplace(SimpleItem3.Filter(), SimpleItem3, options.After,
+		options.FindOneAndReplace().SetUpsert(true))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(item)
+	suite.Equal("three", item.Alpha)
+	suite.NotEqual(after.ID(), item.ID())
+}
+
+func (suite *typedTestSuite) TestFindOneAndDelete() {
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	item, err := suite.typed.FindOneAndDelete(NoFilter(),
+		options.FindOneAndDelete().SetSort(bson.D{{Key: "bravo", Value: -1}}))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(item)
+	suite.Equal("two", item.Alpha)
+	_, err = suite.typed.Find(SimpleItem2.Filter())
+	suite.True(IsNotFound(err))
+	item, err = suite.typed.FindOneAndDelete(SimpleItem2.Filter())
+	suite.True(IsNotFound(err))
+	suite.Nil(item)
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,179 @@
+package mdb
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VersionField is the name of the version number field used by Version.
+const VersionField = "version"
+
+// ErrVersionConflict is returned when an item has been changed since it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
+// Versioned provides an interface to items that use a version number for optimistic concurrency control.
+// When embedding this always use:
+//
+//	mdb.Version `bson:"inline"`
+type Versioned interface {
+	CurrentVersion() int64
+	SetVersion(version int64)
+}
+
+// Version instantiates the Versioned interface.
+type Version struct {
+	VersionNumber int64 `bson:"version"`
+}
+
+// CurrentVersion returns the version number of an item.
+func (v *Version) CurrentVersion() int64 {
+	return v.VersionNumber
+}
+
+// SetVersion sets the version number of an item.
+func (v *Version) SetVersion(version int64) {
+	v.VersionNumber = version
+}
+
+// UpdateVersion updates the item referenced by filter if it has the specified version
+// by applying update operator expressions and incrementing the version.
+// The changes must be a bson.D or bson.M of update operators.
+// If the filter matches an item with a different version ErrVersionConflict is returned.
+func (c *TypedCollection[T]) UpdateVersion(filter bson.D, version int64, changes interface{}, opts ...*options.UpdateOptions) error {
+	if _, ok := any(new(T)).(Versioned); !ok {
+		// Update only increments the version for Versioned collection types.
+		var err error
+		if changes, err = incrementVersion(changes); err != nil {
+			return c.opError("update", filter, err)
+		}
+	}
+	opts = append(opts, options.Update().SetUpsert(false))
+	if err := c.Update(addCondition(filter, versionCondition(version)), changes, opts...); err != nil {
+		return c.versionError("update", filter, err)
+	}
+
+	return nil
+}
+
+// versionError converts a failure to match an item, or a duplicate key from upserting a new copy,
+// into ErrVersionConflict if the filter matches an item without the version condition.
+func (c *TypedCollection[T]) versionError(op string, filter interface{}, err error) error {
+	if !errors.Is(err, ErrNoItemMatch) && !IsNotFound(err) && !IsDuplicate(err) {
+		return err
+	}
+	count, countErr := c.CountDocuments(c.ctx, c.activeFilter(filter), options.Count().SetLimit(1))
+	if countErr != nil {
+		return c.opError(op, filter, countErr)
+	} else if count > 0 {
+		return c.opError(op, filter, ErrVersionConflict)
+	}
+	return err
+}
+
+// versionCondition returns a filter condition matching the version.
+// Version zero also matches items without a version.
+func versionCondition(version int64) bson.E {
+	if version == 0 {
+		return bson.E{Key: VersionField, Value: bson.D{{Key: "$in", Value: bson.A{0, nil}}}}
+	}
+	return bson.E{Key: VersionField, Value: version}
+}
+
+// incrementVersion returns a copy of the changes with the version increment added.
+func incrementVersion(changes interface{}) (interface{}, error) {
+	return addOperator(changes, "$inc", bson.E{Key: VersionField, Value: 1})
+}
+
+// versionedChanges adds incrementing the version to the changes if the collection type T is Versioned.
+// Operator documents get a $inc operator and update pipelines get a final $set stage.
+func versionedChanges[T any](changes interface{}) (interface{}, error) {
+	if _, ok := any(new(T)).(Versioned); !ok {
+		return changes, nil
+	}
+	increment := bson.D{{Key: "$add", Value: bson.A{
+		bson.D{{Key: "$ifNull", Value: bson.A{"$" + VersionField, 0}}}, 1}}}
+	if pipeline, ok := appendStage(changes, bson.D{{Key: "$set", Value: bson.D{{Key: VersionField, Value: increment}}}}); ok {
+		return pipeline, nil
+	}
+	return incrementVersion(changes)
+}
+
+// appendStage returns a copy of an update pipeline with the stage added
+// or false if the changes are not an update pipeline.
+func appendStage(changes interface{}, stage bson.D) (interface{}, bool) {
+	switch pipeline := changes.(type) {
+	case Pipeline:
+		return append(pipeline[:len(pipeline):len(pipeline)], stage), true
+	case mongo.Pipeline:
+		return append(pipeline[:len(pipeline):len(pipeline)], stage), true
+	case []bson.D:
+		return append(pipeline[:len(pipeline):len(pipeline)], stage), true
+	case bson.A:
+		return append(pipeline[:len(pipeline):len(pipeline)], stage), true
+	}
+	return nil, false
+}
+
+// addOperator returns a copy of the bson.D or bson.M changes
+// with the element added to the update operator.
+func addOperator(changes interface{}, operator string, elem bson.E) (interface{}, error) {
+	switch c := changes.(type) {
+	case bson.D:
+		result := make(bson.D, 0, len(c)+1)
+		found := false
+		for _, change := range c {
+			if change.Key == operator {
+				document, err := addToDocument(change.Value, elem)
+				if err != nil {
+					return nil, fmt.Errorf("changes %s: %w", operator, err)
+				}
+				change = bson.E{Key: operator, Value: document}
+				found = true
+			}
+			result = append(result, change)
+		}
+		if !found {
+			result = append(result, bson.E{Key: operator, Value: bson.D{elem}})
+		}
+		return result, nil
+	case bson.M:
+		result := make(bson.M, len(c)+1)
+		for key, value := range c {
+			result[key] = value
+		}
+		if document, found := c[operator]; found {
+			var err error
+			if result[operator], err = addToDocument(document, elem); err != nil {
+				return nil, fmt.Errorf("changes %s: %w", operator, err)
+			}
+		} else {
+			result[operator] = bson.D{elem}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("changes type %T not bson.D or bson.M", changes)
+	}
+}
+
+// addToDocument returns a copy of the bson.D or bson.M document with the element added.
+func addToDocument(document interface{}, elem bson.E) (interface{}, error) {
+	switch d := document.(type) {
+	case bson.D:
+		result := make(bson.D, 0, len(d)+1)
+		result = append(result, d...)
+		return append(result, elem), nil
+	case bson.M:
+		result := make(bson.M, len(d)+1)
+		for key, value := range d {
+			result[key] = value
+		}
+		result[elem.Key] = elem.Value
+		return result, nil
+	default:
+		return nil, fmt.Errorf("document type %T not bson.D or bson.M", document)
+	}
+}
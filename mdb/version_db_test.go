@@ -0,0 +1,168 @@
+//go:build database
+
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type versionDbTestSuite struct {
+	AccessTestSuite
+	typed *TypedCollection[versionedItem]
+}
+
+func TestVersionDbSuite(t *testing.T) {
+	suite.Run(t, new(versionDbTestSuite))
+}
+
+func (suite *versionDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[versionedItem](&suite.AccessTestSuite, testCollectionVersioned,
+		NewIndexDescription(true, "alpha"))
+}
+
+func (suite *versionDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+}
+
+func (suite *versionDbTestSuite) filter() bson.D {
+	return bson.D{{Key: "alpha", Value: "one"}}
+}
+
+func (suite *versionDbTestSuite) TestReplace() {
+	suite.Require().NoError(suite.typed.Create(&versionedItem{Alpha: "one"}))
+	first, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	second, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Zero(first.CurrentVersion())
+	// First editor wins:
+	suite.Require().NoError(suite.typed.Replace(suite.filter(), first))
+	suite.Equal(int64(1), first.CurrentVersion())
+	// Second editor has a stale version:
+	second.Alpha = "two"
+	err = suite.typed.Replace(suite.filter(), second)
+	suite.ErrorIs(err, ErrVersionConflict)
+	suite.Zero(second.CurrentVersion())
+	item, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), item.CurrentVersion())
+	// Reload and try again:
+	item.Alpha = "two"
+	suite.Require().NoError(suite.typed.Replace(suite.filter(), item))
+	suite.Equal(int64(2), item.CurrentVersion())
+	// No match for filter:
+	suite.ErrorIs(suite.typed.Replace(suite.filter(), item), ErrNoItemMatch)
+}
+
+func (suite *versionDbTestSuite) TestReplaceUpsert() {
+	upsert := options.Update().SetUpsert(true)
+	item := &versionedItem{Alpha: "one"}
+	suite.Require().NoError(suite.typed.Replace(suite.filter(), item, upsert))
+	suite.Equal(int64(1), item.CurrentVersion())
+	// Stale item doesn't upsert a copy:
+	stale := &versionedItem{Alpha: "one"}
+	stale.SetVersion(5)
+	suite.ErrorIs(suite.typed.Replace(suite.filter(), stale, upsert), ErrVersionConflict)
+	// New item conflicts with the unique index:
+	suite.ErrorIs(suite.typed.Replace(suite.filter(), &versionedItem{Alpha: "one"}, upsert), ErrVersionConflict)
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *versionDbTestSuite) TestFindOneAndReplace() {
+	suite.Require().NoError(suite.typed.Create(&versionedItem{Alpha: "one"}))
+	item, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	stale := *item
+	replaced, err := suite.typed.FindOneAndReplace(suite.filter(), item, options.After)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), item.CurrentVersion())
+	suite.Equal(int64(1), replaced.CurrentVersion())
+	_, err = suite.typed.FindOneAndReplace(suite.filter(), &stale, options.After)
+	suite.ErrorIs(err, ErrVersionConflict)
+	suite.Zero(stale.CurrentVersion())
+	_, err = suite.typed.FindOneAndReplace(suite.filter(), &versionedItem{Alpha: "one"}, options.After,
+		options.FindOneAndReplace().SetUpsert(true))
+	suite.ErrorIs(err, ErrVersionConflict)
+	found, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), found.CurrentVersion())
+}
+
+func (suite *versionDbTestSuite) TestUpdateVersion() {
+	suite.Require().NoError(suite.typed.Create(&versionedItem{Alpha: "one"}))
+	set := bson.M{"$set": bson.M{"alpha": "one"}}
+	suite.Require().NoError(suite.typed.UpdateVersion(suite.filter(), 0, set))
+	suite.ErrorIs(suite.typed.UpdateVersion(suite.filter(), 0, set), ErrVersionConflict)
+	suite.Require().NoError(suite.typed.UpdateVersion(suite.filter(), 1,
+		bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "one"}}}}))
+	item, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), item.CurrentVersion())
+	suite.ErrorIs(suite.typed.UpdateVersion(bson.D{{Key: "alpha", Value: "two"}}, 2, set), ErrNoItemMatch)
+}
+
+func (suite *versionDbTestSuite) TestUpdate() {
+	suite.Require().NoError(suite.typed.Create(&versionedItem{Alpha: "one"}))
+	stale, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	set := bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "one"}}}}
+	suite.Require().NoError(suite.typed.Update(suite.filter(), set))
+	suite.ErrorIs(suite.typed.Replace(suite.filter(), stale), ErrVersionConflict)
+	updated, err := suite.typed.FindOneAndUpdate(suite.filter(), set, options.After)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), updated.CurrentVersion())
+	suite.Require().NoError(suite.typed.Update(suite.filter(),
+		NewPipeline().Stage("$set", bson.D{{Key: "alpha", Value: "one"}})))
+	found, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), found.CurrentVersion())
+}
+
+func (suite *versionDbTestSuite) TestBulk() {
+	suite.Require().NoError(suite.typed.Create(&versionedItem{Alpha: "one"}))
+	item, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	stale := *item
+	_, err = suite.typed.Bulk().Replace(suite.filter(), item, false).Execute()
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), item.CurrentVersion())
+	result, err := suite.typed.Bulk().
+		Update(suite.filter(), bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "one"}}}}, false).
+		Replace(suite.filter(), &stale, false).Execute()
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.Matched, "stale item not matched")
+	found, err := suite.typed.Find(suite.filter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), found.CurrentVersion())
+	// A new item conflicts with the unique index and keeps its version:
+	fresh := &versionedItem{Alpha: "one"}
+	_, err = suite.typed.Bulk().Replace(suite.filter(), fresh, true).Execute()
+	suite.ErrorIs(err, ErrBulkItemsFailed)
+	suite.Zero(fresh.CurrentVersion())
+}
+
+func (suite *versionDbTestSuite) TestReplaceDocument() {
+	item := &versionedItem{Alpha: "one"}
+	result, err := suite.typed.Upsert(suite.filter(), item)
+	suite.Require().NoError(err)
+	suite.Equal(ItemCreated, result.Status)
+	suite.Equal(int64(1), item.CurrentVersion())
+	stale := *item
+	result, err = suite.typed.ReplaceDocument(suite.filter(), item, false)
+	suite.Require().NoError(err)
+	suite.Equal(ItemReplaced, result.Status)
+	suite.Equal(int64(2), item.CurrentVersion())
+	_, err = suite.typed.Upsert(suite.filter(), &stale)
+	suite.ErrorIs(err, ErrVersionConflict)
+	suite.Equal(int64(1), stale.CurrentVersion())
+	count, err := suite.typed.Count(NoFilter())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+}
@@ -0,0 +1,91 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type versionTestSuite struct {
+	suite.Suite
+}
+
+func TestVersionSuite(t *testing.T) {
+	suite.Run(t, new(versionTestSuite))
+}
+
+type versionedItem struct {
+	Identity `bson:"inline"`
+	Version  `bson:"inline"`
+	Alpha    string
+}
+
+func (suite *versionTestSuite) TestVersion() {
+	item := &versionedItem{Alpha: "one"}
+	var versioned Versioned = item
+	suite.Zero(versioned.CurrentVersion())
+	versioned.SetVersion(3)
+	suite.Equal(int64(3), item.VersionNumber)
+	raw, err := bson.Marshal(item)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), bson.Raw(raw).Lookup(VersionField).Int64())
+}
+
+func (suite *versionTestSuite) TestVersionCondition() {
+	suite.Equal(bson.E{Key: VersionField, Value: int64(2)}, versionCondition(2))
+	suite.Equal(bson.E{Key: VersionField, Value: bson.D{{Key: "$in", Value: bson.A{0, nil}}}}, versionCondition(0))
+}
+
+func (suite *versionTestSuite) TestIncrementVersion() {
+	set := bson.D{{Key: "alpha", Value: "two"}}
+	changes, err := incrementVersion(bson.D{{Key: "$set", Value: set}})
+	suite.Require().NoError(err)
+	suite.Equal(bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$inc", Value: bson.D{{Key: VersionField, Value: 1}}},
+	}, changes)
+	original := bson.D{{Key: "$inc", Value: bson.D{{Key: "delta", Value: 2}}}}
+	changes, err = incrementVersion(original)
+	suite.Require().NoError(err)
+	suite.Equal(bson.D{{Key: "$inc", Value: bson.D{
+		{Key: "delta", Value: 2},
+		{Key: VersionField, Value: 1},
+	}}}, changes)
+	suite.Equal(bson.D{{Key: "$inc", Value: bson.D{{Key: "delta", Value: 2}}}}, original)
+	changes, err = incrementVersion(bson.M{"$inc": bson.M{"delta": 2}})
+	suite.Require().NoError(err)
+	suite.Equal(bson.M{"$inc": bson.M{"delta": 2, VersionField: 1}}, changes)
+	changes, err = incrementVersion(bson.M{"$set": bson.M{"alpha": "two"}})
+	suite.Require().NoError(err)
+	suite.Equal(bson.M{
+		"$set": bson.M{"alpha": "two"},
+		"$inc": bson.D{{Key: VersionField, Value: 1}},
+	}, changes)
+}
+
+func (suite *versionTestSuite) TestVersionedChanges() {
+	set := bson.D{{Key: "$set", Value: bson.D{{Key: "alpha", Value: "two"}}}}
+	changes, err := versionedChanges[SimpleItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(set, changes, "not Versioned")
+	changes, err = versionedChanges[versionedItem](set)
+	suite.Require().NoError(err)
+	suite.Equal(append(set, bson.E{Key: "$inc", Value: bson.D{{Key: VersionField, Value: 1}}}), changes)
+	pipeline := mongo.Pipeline{set}
+	changes, err = versionedChanges[versionedItem](pipeline)
+	suite.Require().NoError(err)
+	suite.Equal(mongo.Pipeline{set, {{Key: "$set", Value: bson.D{{Key: VersionField, Value: bson.D{{Key: "$add",
+		Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$" + VersionField, 0}}}, 1}}}}}}}}, changes)
+	suite.Len(pipeline, 1, "pipeline not changed")
+	_, err = versionedChanges[versionedItem](struct{}{})
+	suite.Error(err)
+}
+
+func (suite *versionTestSuite) TestIncrementVersionUnsupported() {
+	_, err := incrementVersion(struct{}{})
+	suite.Error(err)
+	_, err = incrementVersion(bson.D{{Key: "$inc", Value: "delta"}})
+	suite.Error(err)
+}
@@ -0,0 +1,230 @@
+package mdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent is a change stream event for a typed collection.
+type ChangeEvent[T any] struct {
+	// ResumeToken identifies the event within the change stream.
+	ResumeToken bson.Raw `bson:"_id"`
+
+	// OperationType is the kind of change, for example "insert", "update", "replace", or "delete".
+	OperationType string `bson:"operationType"`
+
+	// DocumentKey holds the _id of the changed document.
+	DocumentKey bson.D `bson:"documentKey"`
+
+	// FullDocument is the changed document.
+	// It is nil for delete events and for update events if the document has since been deleted.
+	FullDocument *T `bson:"fullDocument"`
+
+	// UpdateDescription lists the fields changed by an update event.
+	UpdateDescription *UpdateDescription `bson:"updateDescription"`
+
+	// ClusterTime is the time of the change in the oplog.
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+}
+
+// UpdateDescription lists the fields changed by an update event.
+type UpdateDescription struct {
+	UpdatedFields bson.D   `bson:"updatedFields"`
+	RemovedFields []string `bson:"removedFields"`
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Name identifies the watcher in the Store, default is the collection name.
+	// Use different names for watchers that must restart independently.
+	Name string
+
+	// Store persists the resume token after each event has been handled.
+	// If nil, watching starts with the next change every time.
+	Store ResumeTokenStore
+
+	// ChangeStream options such as batch size or max await time.
+	// The full document option defaults to options.UpdateLookup
+	// and the resume after option is set from the Store if it has a token.
+	// Resume after, start after, or start at operation time options set here take precedence
+	// and the Store token is not used, since the server rejects more than one of them.
+	ChangeStream *options.ChangeStreamOptions
+}
+
+// Watch applies the function to each change stream event for the collection
+// until the function returns an error or the context ends.
+// The pipeline filters or modifies events, it may be nil, a Pipeline, a mongo.Pipeline,
+// or any other pipeline accepted by mongo-go-driver.
+// The full document is decoded into the collection type.
+// Change streams require a replica set or sharded cluster.
+// Soft deleted items are not filtered out, they are seen as update events.
+// Return StopIteration from the function to end watching without an error,
+// otherwise the error from the function or the context is returned.
+// The resume token is saved to the Store only after the function succeeds or returns StopIteration,
+// so an event may be seen again after a restart but is not skipped.
+func (c *TypedCollection[T]) Watch(
+	ctx context.Context, pipeline interface{}, fn func(event ChangeEvent[T]) error, opts ...*WatchOptions) error {
+	opt := &WatchOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	name := opt.Name
+	if name == "" {
+		name = c.Name()
+	}
+	if pipeline == nil {
+		pipeline = NewPipeline()
+	}
+
+	streamOpts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if opt.ChangeStream != nil {
+		streamOpts = options.MergeChangeStreamOptions(streamOpts, opt.ChangeStream)
+	}
+	if opt.Store != nil && !startSet(streamOpts) {
+		token, err := opt.Store.LoadResumeToken(ctx, name)
+		if err != nil {
+			return c.opError("watch", nil, fmt.Errorf("load resume token %s: %w", name, err))
+		}
+		if token != nil {
+			streamOpts.SetResumeAfter(token)
+		}
+	}
+
+	stream, err := c.Collection.Collection.Watch(ctx, pipeline, streamOpts)
+	if err != nil {
+		return c.opError("watch", nil, err)
+	}
+	defer func() { _ = stream.Close(context.Background()) }()
+
+	for stream.Next(ctx) {
+		var event ChangeEvent[T]
+		if err := stream.Decode(&event); err != nil {
+			return c.opError("watch", nil, fmt.Errorf("decode event: %w", err))
+		}
+		if event.FullDocument != nil {
+			if err := afterFind(ctx, event.FullDocument); err != nil {
+				return c.opError("watch", nil, err)
+			}
+		}
+		fnErr := fn(event)
+		if fnErr != nil && !errors.Is(fnErr, StopIteration) {
+			return fmt.Errorf("apply function: %w", fnErr)
+		}
+		if opt.Store != nil {
+			if err := opt.Store.SaveResumeToken(ctx, name, stream.ResumeToken()); err != nil {
+				return c.opError("watch", nil, fmt.Errorf("save resume token %s: %w", name, err))
+			}
+		}
+		if fnErr != nil {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := stream.Err(); err != nil {
+		return c.opError("watch", nil, err)
+	}
+
+	return nil
+}
+
+// startSet returns true if the options specify where the change stream starts.
+func startSet(opts *options.ChangeStreamOptions) bool {
+	return opts.ResumeAfter != nil || opts.StartAfter != nil || opts.StartAtOperationTime != nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ResumeTokenStore persists change stream resume tokens by watcher name
+// so that watchers restart where they stopped.
+type ResumeTokenStore interface {
+	// LoadResumeToken returns the token saved for the name or nil if there is none.
+	LoadResumeToken(ctx context.Context, name string) (bson.Raw, error)
+
+	// SaveResumeToken saves the token for the name, replacing any previous token.
+	SaveResumeToken(ctx context.Context, name string, token bson.Raw) error
+}
+
+var _ ResumeTokenStore = &MemoryTokenStore{}
+
+// MemoryTokenStore keeps resume tokens in memory.
+// It allows a watcher to restart within a process, for example after a network error.
+type MemoryTokenStore struct {
+	tokens map[string]bson.Raw
+	lock   sync.Mutex
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]bson.Raw)}
+}
+
+// LoadResumeToken returns the token saved for the name or nil if there is none.
+func (mts *MemoryTokenStore) LoadResumeToken(_ context.Context, name string) (bson.Raw, error) {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+	return mts.tokens[name], nil
+}
+
+// SaveResumeToken saves a copy of the token for the name.
+func (mts *MemoryTokenStore) SaveResumeToken(_ context.Context, name string, token bson.Raw) error {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+	mts.tokens[name] = append(bson.Raw(nil), token...)
+	return nil
+}
+
+var _ ResumeTokenStore = &CollectionTokenStore{}
+
+// CollectionTokenStore keeps resume tokens in a Mongo collection, one document per watcher name.
+// The collection should not be the collection being watched.
+type CollectionTokenStore struct {
+	collection *Collection
+}
+
+// NewCollectionTokenStore returns a CollectionTokenStore using the specified collection.
+func NewCollectionTokenStore(collection *Collection) *CollectionTokenStore {
+	return &CollectionTokenStore{collection: collection}
+}
+
+type resumeTokenDocument struct {
+	Name    string    `bson:"_id"`
+	Token   bson.Raw  `bson:"token"`
+	Updated time.Time `bson:"updated"`
+}
+
+// LoadResumeToken returns the token saved for the name or nil if there is none.
+func (cts *CollectionTokenStore) LoadResumeToken(ctx context.Context, name string) (bson.Raw, error) {
+	filter := bson.D{{Key: "_id", Value: name}}
+	var document resumeTokenDocument
+	if err := cts.collection.FindOne(ctx, filter).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, cts.collection.opError("load resume token", filter, err)
+	}
+	return document.Token, nil
+}
+
+// SaveResumeToken saves the token for the name, creating the document if necessary.
+func (cts *CollectionTokenStore) SaveResumeToken(ctx context.Context, name string, token bson.Raw) error {
+	filter := bson.D{{Key: "_id", Value: name}}
+	_, err := cts.collection.ReplaceOne(ctx, filter, &resumeTokenDocument{
+		Name:    name,
+		Token:   token,
+		Updated: time.Now().UTC(),
+	}, options.Replace().SetUpsert(true))
+	if err != nil {
+		return cts.collection.opError("save resume token", filter, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+//go:build database
+
+package mdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Change streams require a replica set, a local single node replica set is sufficient:
+//
+//	mongod --replSet rs0
+//	mongosh --eval 'rs.initiate()'
+type watchDbTestSuite struct {
+	AccessTestSuite
+	typed  *TypedCollection[SimpleItem]
+	tokens *Collection
+}
+
+func TestWatchDbSuite(t *testing.T) {
+	suite.Run(t, new(watchDbTestSuite))
+}
+
+func (suite *watchDbTestSuite) SetupSuite() {
+	suite.AccessTestSuite.SetupSuite()
+	suite.typed = ConnectTypedCollectionHelper[SimpleItem](&suite.AccessTestSuite, testCollectionWatched)
+	suite.tokens = suite.ConnectCollection(testCollectionResumeTokens)
+	if suite.operationTime() == nil {
+		suite.T().Skip("change streams require a replica set")
+	}
+}
+
+func (suite *watchDbTestSuite) TearDownTest() {
+	suite.NoError(suite.typed.DeleteAll())
+	suite.NoError(suite.tokens.DeleteAll())
+}
+
+// operationTime returns the current cluster operation time or nil if the server is not a replica set.
+func (suite *watchDbTestSuite) operationTime() *primitive.Timestamp {
+	var hello struct {
+		SetName       string              `bson:"setName"`
+		OperationTime primitive.Timestamp `bson:"operationTime"`
+	}
+	suite.Require().NoError(suite.access.Database().RunCommand(
+		context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello))
+	if hello.SetName == "" {
+		return nil
+	}
+	return &hello.OperationTime
+}
+
+// watchEvents collects the specified number of events.
+func (suite *watchDbTestSuite) watchEvents(count int, opts *WatchOptions) []ChangeEvent[SimpleItem] {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events := make([]ChangeEvent[SimpleItem], 0, count)
+	suite.Require().NoError(suite.typed.Watch(ctx, nil, func(event ChangeEvent[SimpleItem]) error {
+		events = append(events, event)
+		if len(events) >= count {
+			return StopIteration
+		}
+		return nil
+	}, opts))
+	return events
+}
+
+func (suite *watchDbTestSuite) TestWatch() {
+	start := suite.operationTime()
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Update(SimpleItem1.Filter(),
+		bson.D{{Key: "$set", Value: bson.D{{Key: "bravo", Value: 17}}}}))
+	suite.Require().NoError(suite.typed.Delete(SimpleItem1.Filter(), false))
+	events := suite.watchEvents(3, &WatchOptions{
+		ChangeStream: options.ChangeStream().SetStartAtOperationTime(start),
+	})
+	suite.Require().Len(events, 3)
+	suite.Equal("insert", events[0].OperationType)
+	suite.Require().NotNil(events[0].FullDocument)
+	suite.Equal("one", events[0].FullDocument.Alpha)
+	suite.Equal("update", events[1].OperationType)
+	suite.Require().NotNil(events[1].UpdateDescription)
+	suite.Equal(bson.D{{Key: "bravo", Value: int32(17)}}, events[1].UpdateDescription.UpdatedFields)
+	suite.Equal("delete", events[2].OperationType)
+	suite.Nil(events[2].FullDocument)
+}
+
+func (suite *watchDbTestSuite) TestWatchPipeline() {
+	start := suite.operationTime()
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var alpha string
+	suite.Require().NoError(suite.typed.Watch(ctx,
+		NewPipeline().Match(bson.D{{Key: "fullDocument.alpha", Value: "two"}}),
+		func(event ChangeEvent[SimpleItem]) error {
+			alpha = event.FullDocument.Alpha
+			return StopIteration
+		}, &WatchOptions{ChangeStream: options.ChangeStream().SetStartAtOperationTime(start)}))
+	suite.Equal("two", alpha)
+}
+
+func (suite *watchDbTestSuite) TestResume() {
+	store := NewCollectionTokenStore(suite.tokens)
+	start := suite.operationTime()
+	suite.Require().NoError(suite.typed.Create(SimpleItem1))
+	suite.Require().NoError(suite.typed.Create(SimpleItem2))
+	suite.Require().NoError(suite.typed.Create(SimpleItem3))
+	events := suite.watchEvents(1, &WatchOptions{
+		Name:         "resume",
+		Store:        store,
+		ChangeStream: options.ChangeStream().SetStartAtOperationTime(start),
+	})
+	suite.Require().Len(events, 1)
+	suite.Equal("one", events[0].FullDocument.Alpha)
+	token, err := store.LoadResumeToken(context.Background(), "resume")
+	suite.Require().NoError(err)
+	suite.NotNil(token)
+	// Restart where the previous watcher stopped:
+	events = suite.watchEvents(2, &WatchOptions{Name: "resume", Store: store})
+	suite.Require().Len(events, 2)
+	suite.Equal("two", events[0].FullDocument.Alpha)
+	suite.Equal("three", events[1].FullDocument.Alpha)
+}
+
+func (suite *watchDbTestSuite) TestCancel() {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := suite.typed.Watch(ctx, nil, func(event ChangeEvent[SimpleItem]) error {
+		return nil
+	})
+	suite.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,86 @@
+package mdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type watchTestSuite struct {
+	suite.Suite
+}
+
+func TestWatchSuite(t *testing.T) {
+	suite.Run(t, new(watchTestSuite))
+}
+
+func (suite *watchTestSuite) TestDecodeChangeEvent() {
+	token := bson.D{{Key: "_data", Value: "8264A1"}}
+	raw, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: token},
+		{Key: "operationType", Value: "update"},
+		{Key: "documentKey", Value: bson.D{{Key: "_id", Value: "xyz"}}},
+		{Key: "fullDocument", Value: SimpleItem1},
+		{Key: "updateDescription", Value: bson.D{
+			{Key: "updatedFields", Value: bson.D{{Key: "bravo", Value: 1}}},
+			{Key: "removedFields", Value: bson.A{"delta"}},
+		}},
+		{Key: "clusterTime", Value: primitive.Timestamp{T: 17, I: 1}},
+	})
+	suite.Require().NoError(err)
+	var event ChangeEvent[SimpleItem]
+	suite.Require().NoError(bson.Unmarshal(raw, &event))
+	suite.Equal("update", event.OperationType)
+	suite.Equal("8264A1", event.ResumeToken.Lookup("_data").StringValue())
+	suite.Equal(bson.D{{Key: "_id", Value: "xyz"}}, event.DocumentKey)
+	suite.Require().NotNil(event.FullDocument)
+	suite.Equal(SimpleItem1.Alpha, event.FullDocument.Alpha)
+	suite.Require().NotNil(event.UpdateDescription)
+	suite.Equal([]string{"delta"}, event.UpdateDescription.RemovedFields)
+	suite.Equal(primitive.Timestamp{T: 17, I: 1}, event.ClusterTime)
+}
+
+func (suite *watchTestSuite) TestDecodeDeleteEvent() {
+	raw, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: bson.D{{Key: "_data", Value: "8264A2"}}},
+		{Key: "operationType", Value: "delete"},
+		{Key: "documentKey", Value: bson.D{{Key: "_id", Value: "xyz"}}},
+	})
+	suite.Require().NoError(err)
+	var event ChangeEvent[SimpleItem]
+	suite.Require().NoError(bson.Unmarshal(raw, &event))
+	suite.Equal("delete", event.OperationType)
+	suite.Nil(event.FullDocument)
+	suite.Nil(event.UpdateDescription)
+}
+
+func (suite *watchTestSuite) TestMemoryTokenStore() {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	token, err := store.LoadResumeToken(ctx, "alpha")
+	suite.Require().NoError(err)
+	suite.Nil(token)
+	raw, err := bson.Marshal(bson.D{{Key: "_data", Value: "8264A1"}})
+	suite.Require().NoError(err)
+	suite.Require().NoError(store.SaveResumeToken(ctx, "alpha", raw))
+	// Saved token is a copy:
+	raw[len(raw)-3] = 'X'
+	token, err = store.LoadResumeToken(ctx, "alpha")
+	suite.Require().NoError(err)
+	suite.Equal("8264A1", token.Lookup("_data").StringValue())
+	token, err = store.LoadResumeToken(ctx, "bravo")
+	suite.Require().NoError(err)
+	suite.Nil(token)
+}
+
+func (suite *watchTestSuite) TestStartSet() {
+	suite.False(startSet(options.ChangeStream()))
+	suite.False(startSet(options.ChangeStream().SetFullDocument(options.UpdateLookup)))
+	suite.True(startSet(options.ChangeStream().SetResumeAfter(bson.D{{Key: "_data", Value: "token"}})))
+	suite.True(startSet(options.ChangeStream().SetStartAfter(bson.D{{Key: "_data", Value: "token"}})))
+	suite.True(startSet(options.ChangeStream().SetStartAtOperationTime(&primitive.Timestamp{T: 1})))
+}